@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// defaultBaseline is the file name used when `Baseline` isn't set.
+const defaultBaseline = ".vale-baseline.json"
+
+func runBaseline(args []string, flags *core.CLIFlags) error {
+	if len(args) == 0 || args[0] != "create" {
+		return core.NewE100("baseline", errors.New("expected 'create' subcommand"))
+	}
+
+	cfg, err := core.ReadPipeline(flags, false)
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	paths := args[1:]
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	linted, err := doLint(paths, linter, flags.Glob)
+	if err != nil {
+		return err
+	}
+
+	path := cfg.Baseline
+	if path == "" {
+		path = defaultBaseline
+		if root := cfg.ConfigFile(); root != "" {
+			path = filepath.Join(filepath.Dir(root), defaultBaseline)
+		}
+	}
+
+	baseline := core.NewBaseline(linted, filepath.Dir(path))
+	if err = baseline.Write(path); err != nil {
+		return core.NewE100("baseline", err)
+	}
+
+	n := 0
+	for _, e := range baseline.Entries {
+		n += e.Count
+	}
+	fmt.Printf("Wrote %d %s to '%s'.\n", n, pluralize("alert", n), path)
+
+	if cfg.Baseline == "" {
+		fmt.Printf("Add 'Baseline = %s' to your .vale.ini file to use it.\n", defaultBaseline)
+	}
+
+	return nil
+}
+
+// applyBaseline returns copies of `linted` without any known alerts, and
+// reports any stale entries to `w`.
+//
+// `linted` itself is left as-is, which allows the baseline to be re-applied
+// to the same results (e.g., in `--watch` mode).
+func applyBaseline(linted []*core.File, cfg *core.Config, w io.Writer) ([]*core.File, error) {
+	baseline, err := core.ReadBaseline(cfg.Baseline)
+	if err != nil {
+		return linted, err
+	}
+
+	files := make([]*core.File, len(linted))
+	for i, f := range linted {
+		// NOTE: `Suppress` replaces each file's alerts rather than modifying
+		// them, so a shallow copy is enough.
+		clone := *f
+		files[i] = &clone
+	}
+
+	stale := baseline.Suppress(files, filepath.Dir(cfg.Baseline))
+	if len(stale) > 0 {
+		fmt.Fprintf(w, "%d stale baseline %s in '%s' (run 'vale baseline create' to update):\n",
+			len(stale), pluralize("fingerprint", len(stale)), cfg.Baseline)
+		for _, e := range stale {
+			fmt.Fprintf(w, "  %s: %s '%s' (x%d)\n", e.Path, e.Check, e.Match, e.Count)
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+var levelToCheckstyle = map[string]string{
+	"error":      "error",
+	"warning":    "warning",
+	"suggestion": "info",
+}
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// PrintCheckstyleAlerts prints Alerts as a Checkstyle XML report.
+func PrintCheckstyleAlerts(linted []*core.File, w io.Writer) (bool, error) {
+	alertCount := 0
+
+	report := checkstyleReport{Version: "4.3", Files: []checkstyleFile{}}
+	for _, f := range linted {
+		file := checkstyleFile{Name: filepath.ToSlash(f.Path)}
+		for _, a := range fileAlerts(f) {
+			if a.Severity == "error" {
+				alertCount++
+			}
+
+			severity, ok := levelToCheckstyle[a.Severity]
+			if !ok {
+				severity = "warning"
+			}
+
+			file.Errors = append(file.Errors, checkstyleError{
+				Line:     a.Line,
+				Column:   a.Span[0],
+				Severity: severity,
+				Message:  a.Message,
+				Source:   a.Check,
+			})
+		}
+		report.Files = append(report.Files, file)
+	}
+
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return false, core.NewE100("checkstyle", err)
+	}
+	fmt.Fprintln(w, xml.Header+string(b))
+
+	return alertCount != 0, nil
+}
@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"strings"
 
 	"github.com/olekukonko/tablewriter"
@@ -12,15 +12,15 @@ import (
 )
 
 // PrintVerboseAlerts prints Alerts in verbose format.
-func PrintVerboseAlerts(linted []*core.File, wrap bool) bool {
+func PrintVerboseAlerts(linted []*core.File, wrap bool, w io.Writer) bool {
 	var errors, warnings, suggestions int
-	var e, w, s int
+	var e, wn, s int
 	var symbol string
 
 	for _, f := range linted {
-		e, w, s = printVerboseAlert(f, wrap)
+		e, wn, s = printVerboseAlert(f, wrap, w)
 		errors += e
-		warnings += w
+		warnings += wn
 		suggestions += s
 	}
 
@@ -36,11 +36,11 @@ func PrintVerboseAlerts(linted []*core.File, wrap bool) bool {
 
 	n := len(linted)
 	if n == 1 && strings.HasPrefix(linted[0].Path, "stdin") {
-		fmt.Printf("%s %s, %s and %s in %s.\n", symbol,
+		fmt.Fprintf(w, "%s %s, %s and %s in %s.\n", symbol,
 			pterm.Red(etotal), pterm.Yellow(wtotal),
 			pterm.Blue(stotal), "stdin")
 	} else {
-		fmt.Printf("%s %s, %s and %s in %d %s.\n", symbol,
+		fmt.Fprintf(w, "%s %s, %s and %s in %d %s.\n", symbol,
 			pterm.Red(etotal), pterm.Yellow(wtotal),
 			pterm.Blue(stotal), n, pluralize("file", n))
 	}
@@ -49,7 +49,7 @@ func PrintVerboseAlerts(linted []*core.File, wrap bool) bool {
 }
 
 // printVerboseAlert includes an alert's line, column, level, and message.
-func printVerboseAlert(f *core.File, wrap bool) (int, int, int) {
+func printVerboseAlert(f *core.File, wrap bool, w io.Writer) (int, int, int) {
 	var loc, level string
 	var errors, warnings, notifications int
 
@@ -58,13 +58,13 @@ func printVerboseAlert(f *core.File, wrap bool) (int, int, int) {
 		return 0, 0, 0
 	}
 
-	table := tablewriter.NewWriter(os.Stdout)
+	table := tablewriter.NewWriter(w)
 	table.SetCenterSeparator("")
 	table.SetColumnSeparator("")
 	table.SetRowSeparator("")
 	table.SetAutoWrapText(!wrap)
 
-	fmt.Printf("\n %s", pterm.Underscore.Sprint(f.Path))
+	fmt.Fprintf(w, "\n %s", pterm.Underscore.Sprint(f.Path))
 	for _, a := range alerts {
 		switch a.Severity {
 		case "suggestion":
@@ -77,7 +77,7 @@ func printVerboseAlert(f *core.File, wrap bool) (int, int, int) {
 			level = pterm.Red(a.Severity)
 			errors++
 		}
-		loc = fmt.Sprintf("%d:%d", a.Line, a.Span[0])
+		loc = location(a)
 		table.Append([]string{loc, level, a.Message, a.Check})
 	}
 	table.Render()
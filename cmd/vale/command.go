@@ -34,9 +34,13 @@ var commandInfo = map[string]string{
 	"ls-dirs":        "Print the default configuration directories to stdout.",
 	"ls-vars":        "Print the supported environment variables to stdout.",
 	"sync":           "Download and install external configuration sources.",
+	"ls-server":      "Start a Language Server Protocol (LSP) server over stdio.",
+	"serve":          "Start an HTTP server for linting (see --addr).",
+	"baseline":       "Create a baseline file that suppresses all current alerts.",
+	"test":           "Run the rule fixtures stored in the given style's 'tests' directory.",
 	"host-install":   "Install the Vale native messaging host for the given browser.",
 	"host-uninstall": "Uninstall the Vale native messaging host for the given browser.",
-	"fix":            "Attempt to automatically fix the given alert.",
+	"fix":            "Attempt to automatically fix the given alert or files.",
 }
 
 // Actions are the available CLI commands.
@@ -46,6 +50,10 @@ var Actions = map[string]func(args []string, flags *core.CLIFlags) error{
 	"ls-dirs":    printDirs,
 	"ls-vars":    printVars,
 	"sync":       sync,
+	"ls-server":  runLanguageServer,
+	"serve":      runServer,
+	"baseline":   runBaseline,
+	"test":       testStyle,
 
 	// private
 	"host-install":   installNativeHost,
@@ -61,6 +69,10 @@ var Actions = map[string]func(args []string, flags *core.CLIFlags) error{
 }
 
 func fix(args []string, flags *core.CLIFlags) error {
+	if flags.Write || flags.DryRun {
+		return fixFiles(args, flags)
+	}
+
 	if len(args) != 1 {
 		return core.NewE100("fix", errors.New("one argument expected"))
 	}
@@ -240,7 +252,7 @@ func runRule(args []string, _ *core.CLIFlags) error {
 		return err
 	}
 
-	PrintJSONAlerts(linted)
+	PrintJSONAlerts(linted, os.Stdout)
 	return nil
 }
 
@@ -1,24 +1,144 @@
 package main
 
 import (
+	"io"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 
+	"github.com/errata-ai/vale/v3/internal/check"
 	"github.com/errata-ai/vale/v3/internal/core"
 )
 
-// PrintAlerts prints the given alerts in the user-specified format.
-func PrintAlerts(linted []*core.File, config *core.Config) (bool, error) {
+// An outputSink is an output style and where to write it.
+type outputSink struct {
+	style string
+	path  string // empty for stdout
+}
+
+// parseOutput splits an `--output` value of the form `style[:path]` (e.g.,
+// `JSON:reports/vale.json`).
+//
+// Single-letter prefixes are treated as Windows drive letters rather than
+// styles (e.g., `C:\vale.tmpl`).
+func parseOutput(spec string) outputSink {
+	start := 0
+	if len(spec) > 1 && spec[1] == ':' {
+		start = 2
+	}
+
+	if i := strings.IndexByte(spec[start:], ':'); i >= 0 {
+		return outputSink{style: spec[:start+i], path: spec[start+i+1:]}
+	}
+	return outputSink{style: spec}
+}
+
+// resolveOutputs returns the given `--output` values with at most one of them
+// written to stdout: a later bare style replaces any earlier one (and, since
+// setting the flag replaces its default, the default "CLI").
+func resolveOutputs(specs []string) []string {
+	last := -1
+	for i, spec := range specs {
+		if parseOutput(spec).path == "" {
+			last = i
+		}
+	}
+
+	resolved := []string{}
+	for i, spec := range specs {
+		if parseOutput(spec).path != "" || i == last {
+			resolved = append(resolved, spec)
+		}
+	}
+
+	return resolved
+}
+
+// primaryOutput is the style used for everything other than alerts (e.g.,
+// errors): the one written to stdout, if any.
+func primaryOutput(specs []string) string {
+	if len(specs) == 0 {
+		return "CLI"
+	}
+	for _, spec := range specs {
+		if sink := parseOutput(spec); sink.path == "" {
+			return sink.style
+		}
+	}
+	return parseOutput(specs[0]).style
+}
+
+// PrintAlerts prints the given alerts in each of the user-specified formats.
+func PrintAlerts(linted []*core.File, mgr *check.Manager) (bool, error) {
+	config := mgr.Config
 	if config.Flags.Sorted {
 		sort.Sort(core.ByName(linted))
 	}
-	switch config.Flags.Output {
+
+	specs := resolveOutputs(config.Flags.Outputs)
+	if len(specs) == 0 {
+		specs = []string{config.Flags.Output}
+	}
+
+	hasErrors := false
+	for _, spec := range specs {
+		sink := parseOutput(spec)
+
+		found, err := printSink(linted, mgr, sink)
+		if err != nil {
+			return hasErrors, err
+		}
+		hasErrors = hasErrors || found
+	}
+
+	return hasErrors, nil
+}
+
+func printSink(linted []*core.File, mgr *check.Manager, sink outputSink) (bool, error) {
+	if sink.path == "" {
+		return printAlerts(linted, mgr, sink.style, os.Stdout)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(sink.path), os.ModePerm); err != nil {
+		return false, core.NewE100("output", err)
+	}
+
+	f, err := os.Create(sink.path)
+	if err != nil {
+		return false, core.NewE100("output", err)
+	}
+
+	hasErrors, err := printAlerts(linted, mgr, sink.style, f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = core.NewE100("output", closeErr)
+	}
+
+	return hasErrors, err
+}
+
+func printAlerts(linted []*core.File, mgr *check.Manager, style string, w io.Writer) (bool, error) {
+	config := mgr.Config
+	switch style {
 	case "JSON":
-		return PrintJSONAlerts(linted), nil
+		return PrintJSONAlerts(linted, w), nil
 	case "line":
-		return PrintLineAlerts(linted, config.Flags.Relative), nil
+		return PrintLineAlerts(linted, config.Flags.Relative, w), nil
+	case "sarif":
+		return PrintSARIFAlerts(linted, mgr, w), nil
+	case "github":
+		return PrintGitHubAlerts(linted, w), nil
+	case "rdjson":
+		return PrintRDJSONAlerts(linted, config, w), nil
+	case "rdjsonl":
+		return PrintRDJSONLAlerts(linted, config, w), nil
+	case "checkstyle":
+		return PrintCheckstyleAlerts(linted, w)
+	case "junit":
+		return PrintJUnitAlerts(linted, w)
 	case "CLI":
-		return PrintVerboseAlerts(linted, config.Flags.Wrap), nil
+		return PrintVerboseAlerts(linted, config.Flags.Wrap, w), nil
 	default:
-		return PrintCustomAlerts(linted, config)
+		return PrintCustomAlerts(linted, config, style, w)
 	}
 }
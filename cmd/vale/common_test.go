@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseOutput(t *testing.T) {
+	cases := map[string]outputSink{
+		"CLI":                            {style: "CLI"},
+		"JSON:vale.json":                 {style: "JSON", path: "vale.json"},
+		"line:reports/vale.txt":          {style: "line", path: "reports/vale.txt"},
+		"templates/junit.tmpl:junit.xml": {style: "templates/junit.tmpl", path: "junit.xml"},
+		`C:\templates\junit.tmpl`:        {style: `C:\templates\junit.tmpl`},
+		`C:\junit.tmpl:D:\out\junit.xml`: {style: `C:\junit.tmpl`, path: `D:\out\junit.xml`},
+		`JSON:C:\reports\vale.json`:      {style: "JSON", path: `C:\reports\vale.json`},
+	}
+
+	for spec, expected := range cases {
+		if got := parseOutput(spec); got != expected {
+			t.Errorf("parseOutput(%q) = %+v, expected %+v", spec, got, expected)
+		}
+	}
+
+	if got := primaryOutput([]string{"JSON:vale.json", "line"}); got != "line" {
+		t.Errorf("expected the stdout style to be primary, got '%s'", got)
+	}
+
+	if got := primaryOutput([]string{"JSON:vale.json", "line:vale.txt"}); got != "JSON" {
+		t.Errorf("expected the first style to be primary, got '%s'", got)
+	}
+}
+
+func TestResolveOutputs(t *testing.T) {
+	cases := []struct {
+		specs    []string
+		expected []string
+	}{
+		{[]string{"CLI"}, []string{"CLI"}},
+		{[]string{"line", "line.tmpl"}, []string{"line.tmpl"}},
+		{[]string{"line", "JSON:vale.json", "github"}, []string{"JSON:vale.json", "github"}},
+		{[]string{"JSON:vale.json", "line:vale.txt"}, []string{"JSON:vale.json", "line:vale.txt"}},
+	}
+
+	for _, c := range cases {
+		got := resolveOutputs(c.specs)
+		if strings.Join(got, ",") != strings.Join(c.expected, ",") {
+			t.Errorf("resolveOutputs(%q) = %q, expected %q", c.specs, got, c.expected)
+		}
+	}
+
+	if got := primaryOutput(resolveOutputs([]string{"line", "JSON"})); got != "JSON" {
+		t.Errorf("expected the last bare style to be primary, got '%s'", got)
+	}
+}
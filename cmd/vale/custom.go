@@ -1,6 +1,7 @@
 package main
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"text/template"
@@ -24,10 +25,9 @@ type Data struct {
 }
 
 // PrintCustomAlerts formats the given alerts using a user-defined template.
-func PrintCustomAlerts(linted []*core.File, cfg *core.Config) (bool, error) {
+func PrintCustomAlerts(linted []*core.File, cfg *core.Config, path string, w io.Writer) (bool, error) {
 	var alertCount int
 
-	path := cfg.Flags.Output
 	if !system.FileExists(path) {
 		path = core.FindAsset(cfg, path)
 	}
@@ -60,7 +60,7 @@ func PrintCustomAlerts(linted []*core.File, cfg *core.Config) (bool, error) {
 		})
 	}
 
-	return alertCount != 0, t.Execute(os.Stdout, Data{
+	return alertCount != 0, t.Execute(w, Data{
 		Files:       formatted,
 		LintedTotal: len(linted),
 	})
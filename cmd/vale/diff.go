@@ -0,0 +1,83 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+	"github.com/errata-ai/vale/v3/internal/system"
+)
+
+// doLintDiff lints the files that have changed since `flags.Diff`, only
+// reporting alerts that fall on added or modified lines.
+//
+// If `args` is non-empty, only changed files located inside one of the
+// given paths are linted.
+func doLintDiff(args []string, l *lint.Linter, flags *core.CLIFlags) ([]*core.File, error) {
+	changes, err := system.GitChanges(flags.Diff)
+	if err != nil {
+		return []*core.File{}, core.NewE100("--diff", err)
+	}
+
+	roots := []string{}
+	for _, arg := range args {
+		root := system.AbsPath(arg)
+		if resolved, rerr := filepath.EvalSymlinks(root); rerr == nil {
+			// `git` always reports resolved paths.
+			root = resolved
+		}
+		roots = append(roots, root)
+	}
+
+	files := []string{}
+	for file := range changes {
+		if len(roots) == 0 || inAnyRoot(file, roots) {
+			files = append(files, file)
+		}
+	}
+
+	if len(files) == 0 {
+		return []*core.File{}, nil
+	}
+
+	linted, err := l.Lint(files, flags.Glob)
+	if err != nil {
+		return linted, err
+	}
+
+	// NOTE: `--normalize` may have converted each path to slash form, so we
+	// compare both sides in that form.
+	normalized := map[string][]system.LineRange{}
+	for file, ranges := range changes {
+		normalized[filepath.ToSlash(file)] = ranges
+	}
+
+	for _, f := range linted {
+		ranges := normalized[filepath.ToSlash(system.AbsPath(filepath.FromSlash(f.Path)))]
+
+		// NOTE: The hunks address lines of the file itself, so notebook
+		// alerts can't stay relative to their cells.
+		alerts := []core.Alert{}
+		for _, a := range lint.LocateCells(f.Content, f.Alerts) {
+			for _, r := range ranges {
+				if r.Contains(a.Line) {
+					alerts = append(alerts, a)
+					break
+				}
+			}
+		}
+		f.Alerts = alerts
+	}
+
+	return linted, nil
+}
+
+func inAnyRoot(file string, roots []string) bool {
+	for _, root := range roots {
+		if file == root || strings.HasPrefix(file, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
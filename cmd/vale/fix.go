@@ -0,0 +1,346 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+	"github.com/errata-ai/vale/v3/internal/system"
+)
+
+// diffContext is the number of unchanged lines shown around each hunk.
+const diffContext = 3
+
+// textEdit is a replacement of the bytes [start, end) of a file.
+type textEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+// fixFiles lints the given files and applies every fix that doesn't require
+// human judgment.
+//
+// With `--dry-run`, the changes are printed as a unified diff instead of being
+// written to disk.
+func fixFiles(args []string, flags *core.CLIFlags) error {
+	if len(args) == 0 {
+		return core.NewE100("fix", errors.New("at least one path expected"))
+	}
+
+	for _, arg := range args {
+		if looksLikeStdin(arg) == 1 {
+			return core.NewE100("fix", fmt.Errorf("argument '%s' does not exist", arg))
+		}
+	}
+
+	cfg, err := core.ReadPipeline(flags, false)
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	linted, err := doLint(args, linter, flags.Glob)
+	if err != nil {
+		return err
+	}
+	sort.Sort(core.ByName(linted))
+
+	fixed, files := 0, 0
+	for _, f := range linted {
+		if len(f.Alerts) == 0 || !system.FileExists(f.Path) {
+			continue
+		}
+
+		b, rerr := os.ReadFile(f.Path)
+		if rerr != nil {
+			return core.NewE100("fix", rerr)
+		}
+		original := string(b)
+
+		edits := resolveEdits(collectEdits(f, original, cfg))
+		if len(edits) == 0 {
+			continue
+		}
+		updated := applyEdits(original, edits)
+
+		if flags.DryRun {
+			fmt.Print(unifiedDiff(f.Path, original, updated))
+		} else {
+			info, serr := os.Stat(f.Path)
+			if serr != nil {
+				return core.NewE100("fix", serr)
+			}
+			if werr := os.WriteFile(f.Path, []byte(updated), info.Mode()); werr != nil {
+				return core.NewE100("fix", werr)
+			}
+		}
+
+		fixed += len(edits)
+		files++
+	}
+
+	if !flags.DryRun {
+		fmt.Printf("Fixed %d %s in %d %s.\n",
+			fixed, pluralize("alert", fixed), files, pluralize("file", files))
+	}
+
+	return nil
+}
+
+// collectEdits converts each of f's alerts into an edit, skipping those
+// whose action is ambiguous (e.g., multiple suggestions) or whose location
+// can't be verified against the original text.
+func collectEdits(f *core.File, original string, cfg *core.Config) []textEdit {
+	edits := []textEdit{}
+
+	for _, a := range f.SortedAlerts() {
+		if !isUnambiguous(a.Action) || a.Cell > 0 {
+			// NOTE: Notebook alerts are located within their cell's source
+			// rather than the file itself.
+			continue
+		}
+
+		suggestions, err := check.FixAlert(a, cfg)
+		if err != nil || len(suggestions) != 1 {
+			continue
+		}
+
+		start, end, ok := spanToOffsets(original, a.Line, a.Span)
+		if !ok || core.Sanitize(original[start:end]) != a.Match {
+			// Our location is fuzzy in some formats, so we only edit text
+			// that we know is what the rule actually matched.
+			continue
+		}
+
+		edits = append(edits, textEdit{start: start, end: end, text: suggestions[0]})
+	}
+
+	return edits
+}
+
+func isUnambiguous(action core.Action) bool {
+	switch action.Name {
+	case "replace":
+		return len(action.Params) == 1
+	case "remove", "edit":
+		return true
+	}
+	return false
+}
+
+// resolveEdits sorts the given edits by position and drops any edit that
+// overlaps one that precedes it.
+func resolveEdits(edits []textEdit) []textEdit {
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].start < edits[j].start
+	})
+
+	resolved := []textEdit{}
+	for _, e := range edits {
+		if n := len(resolved); n > 0 && e.start < resolved[n-1].end {
+			continue
+		}
+		resolved = append(resolved, e)
+	}
+
+	return resolved
+}
+
+// applyEdits applies the given (sorted, non-overlapping) edits to s.
+func applyEdits(s string, edits []textEdit) string {
+	var sb strings.Builder
+
+	last := 0
+	for _, e := range edits {
+		sb.WriteString(s[last:e.start])
+		sb.WriteString(e.text)
+		last = e.end
+	}
+	sb.WriteString(s[last:])
+
+	return sb.String()
+}
+
+// spanToOffsets converts an alert's location -- a 1-based line and an
+// inclusive, 1-based span of runes -- into byte offsets into s.
+//
+// Alerts are located in sanitized text (see `core.Sanitize`), so we account
+// for sequences that were collapsed into a single character.
+func spanToOffsets(s string, line int, span []int) (int, int, bool) {
+	if line < 1 || len(span) != 2 || span[0] < 1 || span[1] < span[0] {
+		return 0, 0, false
+	}
+
+	i := 0
+	for n := 1; n < line; n++ {
+		idx := strings.IndexByte(s[i:], '\n')
+		if idx < 0 {
+			return 0, 0, false
+		}
+		i += idx + 1
+	}
+
+	start, col := -1, 1
+	for i < len(s) && s[i] != '\n' {
+		if col == span[0] {
+			start = i
+		}
+
+		switch {
+		case strings.HasPrefix(s[i:], "&rsquo;"):
+			i += len("&rsquo;")
+		case strings.HasPrefix(s[i:], "\r\n"):
+			return 0, 0, false
+		default:
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+		}
+
+		if col == span[1] {
+			return start, i, start >= 0
+		}
+		col++
+	}
+
+	return 0, 0, false
+}
+
+// unifiedDiff returns a unified diff between a and b, which are the old and
+// new contents of the file at path.
+func unifiedDiff(path, a, b string) string {
+	if a == b {
+		return ""
+	}
+
+	before, after := splitLines(a), splitLines(b)
+
+	type op struct {
+		kind byte // ' ', '-', or '+'
+		line string
+	}
+
+	// Trim the common prefix and suffix to keep the LCS table small.
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+
+	x, y := before[prefix:len(before)-suffix], after[prefix:len(after)-suffix]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := []op{}
+	for _, l := range before[:prefix] {
+		ops = append(ops, op{' ', l})
+	}
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			ops = append(ops, op{' ', x[i]})
+			i++
+			j++
+		case j == len(y) || (i < len(x) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, op{'-', x[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', y[j]})
+			j++
+		}
+	}
+	for _, l := range before[len(before)-suffix:] {
+		ops = append(ops, op{' ', l})
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", path, path)
+
+	for k := 0; k < len(ops); {
+		if ops[k].kind == ' ' {
+			k++
+			continue
+		}
+
+		// Extend the hunk until we see more than 2*diffContext unchanged
+		// lines in a row.
+		first := max(k-diffContext, 0)
+		last := k
+		for m := k; m < len(ops); m++ {
+			if ops[m].kind != ' ' {
+				last = m
+			} else if m-last > 2*diffContext {
+				break
+			}
+		}
+		end := min(last+diffContext+1, len(ops))
+
+		oldStart, newStart := 1, 1
+		for _, o := range ops[:first] {
+			if o.kind != '+' {
+				oldStart++
+			}
+			if o.kind != '-' {
+				newStart++
+			}
+		}
+
+		oldLen, newLen := 0, 0
+		for _, o := range ops[first:end] {
+			if o.kind != '+' {
+				oldLen++
+			}
+			if o.kind != '-' {
+				newLen++
+			}
+		}
+
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", oldStart, oldLen, newStart, newLen)
+		for _, o := range ops[first:end] {
+			sb.WriteByte(o.kind)
+			sb.WriteString(o.line)
+			if !strings.HasSuffix(o.line, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		k = end
+	}
+
+	return sb.String()
+}
+
+// splitLines splits s into lines, keeping their line endings.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
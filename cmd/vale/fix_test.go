@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestSpanToOffsets(t *testing.T) {
+	text := "# Title\n\nIt&rsquo;s café time.\r\n"
+
+	start, end, ok := spanToOffsets(text, 3, []int{6, 9})
+	if !ok {
+		t.Fatal("expected a valid location")
+	}
+
+	if text[start:end] != "café" {
+		t.Fatalf("expected 'café', got '%s'", text[start:end])
+	}
+}
+
+func TestResolveEdits(t *testing.T) {
+	edits := resolveEdits([]textEdit{
+		{start: 10, end: 15, text: "b"},
+		{start: 0, end: 5, text: "a"},
+		{start: 12, end: 20, text: "c"},
+	})
+
+	if len(edits) != 2 || edits[0].text != "a" || edits[1].text != "b" {
+		t.Fatalf("unexpected edits: %v", edits)
+	}
+
+	if s := applyEdits("01234567890123456789", edits); s != "a56789b56789" {
+		t.Fatalf("unexpected result: '%s'", s)
+	}
+}
@@ -23,16 +23,35 @@ func init() {
 		fmt.Sprintf(`A glob pattern (%s)`, toCodeStyle(`--glob='*.{md,txt}.'`)))
 	pflag.StringVar(&Flags.Path, "config", "",
 		fmt.Sprintf(`A file path (%s).`, toCodeStyle(`--config='some/file/path/.vale.ini'`)))
-	pflag.StringVar(&Flags.Output, "output", "CLI", `An output style ("line", "JSON", or a template file).`)
+	pflag.StringArrayVar(&Flags.Outputs, "output", []string{"CLI"},
+		fmt.Sprintf(`An output style ("line", "JSON", "sarif", "github", "rdjson", "rdjsonl", "checkstyle", "junit", or a template file), optionally written to a file (%s). Can be repeated, but only the last style without a file is written to stdout.`,
+			toCodeStyle(`--output=JSON:vale.json`)))
 	pflag.StringVar(&Flags.InExt, "ext", ".txt",
 		fmt.Sprintf(`An extension to associate with stdin (%s).`, toCodeStyle(`--ext=.md`)))
 
+	pflag.StringVar(&Flags.Diff, "diff", "",
+		fmt.Sprintf(`Only report alerts on lines changed since a git revision (%s).`, toCodeStyle(`--diff=main`)))
+
 	pflag.StringVar(&Flags.AlertLevel, "minAlertLevel", "",
 		fmt.Sprintf(`The minimum level to display (%s).`, toCodeStyle(`--minAlertLevel=error`)))
 
 	pflag.BoolVar(&Flags.Wrap, "no-wrap", false, "Don't wrap CLI output.")
 	pflag.BoolVar(&Flags.NoExit, "no-exit", false, "Don't return a nonzero exit code on errors.")
 	pflag.BoolVar(&Flags.Simple, "ignore-syntax", false, "Lint all files line-by-line.")
+	pflag.BoolVar(&Flags.Write, "write", false,
+		fmt.Sprintf(`Apply fixes in place (%s).`, toCodeStyle(`vale fix --write docs/`)))
+	pflag.BoolVar(&Flags.DryRun, "dry-run", false,
+		fmt.Sprintf(`Print fixes as a unified diff (%s).`, toCodeStyle(`vale fix --dry-run docs/`)))
+	pflag.BoolVar(&Flags.Cache, "cache", false,
+		"Skip re-linting unchanged files by caching their alerts.")
+	pflag.StringVar(&Flags.CacheDir, "cache-dir", "",
+		"The directory in which to store cached alerts.")
+	pflag.StringVar(&Flags.Addr, "addr", "127.0.0.1:7777",
+		fmt.Sprintf(`The address for %s to listen on.`, toCodeStyle(`vale serve`)))
+	pflag.BoolVar(&Flags.Watch, "watch", false,
+		fmt.Sprintf(`Re-lint files as they change (%s).`, toCodeStyle(`vale --watch docs/`)))
+	pflag.BoolVar(&Flags.Profile, "profile", false,
+		"Print the time spent on each rule and format to stderr.")
 	pflag.BoolVarP(&Flags.Version, "version", "v", false, "Print the current version.")
 	pflag.BoolVarP(&Flags.Help, "help", "h", false, "Print this help message.")
 
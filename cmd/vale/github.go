@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+var levelToGitHub = map[string]string{
+	"error":      "error",
+	"warning":    "warning",
+	"suggestion": "notice",
+}
+
+// See https://github.com/actions/toolkit/blob/main/packages/core/src/command.ts.
+var githubData = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+var githubProperty = strings.NewReplacer(
+	"%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+
+// PrintGitHubAlerts prints Alerts as GitHub Actions workflow commands, which
+// GitHub displays as annotations on the affected lines.
+//
+// See https://docs.github.com/en/actions/reference/workflow-commands-for-github-actions.
+func PrintGitHubAlerts(linted []*core.File, w io.Writer) bool {
+	alertCount := 0
+	for _, f := range linted {
+		path := githubProperty.Replace(filepath.ToSlash(f.Path))
+		for _, a := range fileAlerts(f) {
+			if a.Severity == "error" {
+				alertCount++
+			}
+
+			level, ok := levelToGitHub[a.Severity]
+			if !ok {
+				level = "warning"
+			}
+
+			fmt.Fprintf(w, "::%s file=%s,line=%d,col=%d,endColumn=%d,title=%s::%s\n",
+				level, path, a.Line, a.Span[0], a.Span[1],
+				githubProperty.Replace(a.Check), githubData.Replace(a.Message))
+		}
+	}
+	return alertCount != 0
+}
@@ -2,12 +2,13 @@ package main
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/errata-ai/vale/v3/internal/core"
 )
 
 // PrintJSONAlerts prints Alerts in map[file.path][]Alert form.
-func PrintJSONAlerts(linted []*core.File) bool {
+func PrintJSONAlerts(linted []*core.File, w io.Writer) bool {
 	alertCount := 0
 	formatted := map[string][]core.Alert{}
 	for _, f := range linted {
@@ -18,6 +19,6 @@ func PrintJSONAlerts(linted []*core.File) bool {
 			formatted[f.Path] = append(formatted[f.Path], a)
 		}
 	}
-	fmt.Println(getJSON(formatted))
+	fmt.Fprintln(w, getJSON(formatted))
 	return alertCount != 0
 }
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+type junitReport struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// PrintJUnitAlerts prints Alerts as a JUnit XML report.
+//
+// Each file is a test suite and each alert is a failed test case; files
+// without any alerts have a single passing test case.
+func PrintJUnitAlerts(linted []*core.File, w io.Writer) (bool, error) {
+	alertCount := 0
+
+	report := junitReport{Name: "vale", Suites: []junitSuite{}}
+	for _, f := range linted {
+		path := filepath.ToSlash(f.Path)
+
+		suite := junitSuite{Name: path}
+		for _, a := range fileAlerts(f) {
+			if a.Severity == "error" {
+				alertCount++
+			}
+
+			location := fmt.Sprintf("%s:%d:%d", path, a.Line, a.Span[0])
+			suite.Cases = append(suite.Cases, junitCase{
+				Name:      a.Check,
+				ClassName: location,
+				Failure: &junitFailure{
+					Message: a.Message,
+					Type:    a.Severity,
+					Text:    fmt.Sprintf("%s: %s (%s)", location, a.Message, a.Check),
+				},
+			})
+		}
+
+		suite.Failures = len(suite.Cases)
+		if suite.Failures == 0 {
+			suite.Cases = append(suite.Cases, junitCase{Name: path, ClassName: path})
+		}
+		suite.Tests = len(suite.Cases)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return false, core.NewE100("junit", err)
+	}
+	fmt.Fprintln(w, xml.Header+string(b))
+
+	return alertCount != 0, nil
+}
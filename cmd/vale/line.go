@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,7 +11,7 @@ import (
 )
 
 // PrintLineAlerts prints Alerts in <path>:<line>:<col>:<check>:<message> format.
-func PrintLineAlerts(linted []*core.File, relative bool) bool {
+func PrintLineAlerts(linted []*core.File, relative bool, w io.Writer) bool {
 	var base string
 
 	exeDir, _ := filepath.Abs(filepath.Dir(os.Args[0]))
@@ -29,11 +30,11 @@ func PrintLineAlerts(linted []*core.File, relative bool) bool {
 			base = f.Path
 		}
 
-		for _, a := range f.SortedAlerts() {
+		for _, a := range fileAlerts(f) {
 			if a.Severity == "error" {
 				alertCount++
 			}
-			fmt.Printf("%s:%d:%d:%s:%s\n",
+			fmt.Fprintf(w, "%s:%d:%d:%s:%s\n",
 				base, a.Line, a.Span[0], a.Check, a.Message)
 		}
 	}
@@ -0,0 +1,562 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+	"github.com/errata-ai/vale/v3/internal/system"
+)
+
+// JSON-RPC error codes used by the language server.
+const (
+	lspParseError     = -32700
+	lspMethodNotFound = -32601
+	lspInternalError  = -32603
+)
+
+// LSP diagnostic severities.
+var levelToSeverity = map[string]int{
+	"error":      1,
+	"warning":    2,
+	"suggestion": 3,
+}
+
+var errExitWithoutShutdown = errors.New("received 'exit' before 'shutdown'")
+
+type lspMessage struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+type lspResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  interface{}      `json:"result"`
+	Error   *lspError        `json:"error,omitempty"`
+}
+
+type lspNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspCodeDescription struct {
+	Href string `json:"href"`
+}
+
+type lspDiagnostic struct {
+	Range           lspRange            `json:"range"`
+	Severity        int                 `json:"severity"`
+	Code            string              `json:"code"`
+	CodeDescription *lspCodeDescription `json:"codeDescription,omitempty"`
+	Source          string              `json:"source"`
+	Message         string              `json:"message"`
+}
+
+type lspTextEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type lspWorkspaceEdit struct {
+	Changes map[string][]lspTextEdit `json:"changes"`
+}
+
+type lspCodeAction struct {
+	Title       string           `json:"title"`
+	Kind        string           `json:"kind"`
+	Diagnostics []lspDiagnostic  `json:"diagnostics"`
+	Edit        lspWorkspaceEdit `json:"edit"`
+	IsPreferred bool             `json:"isPreferred"`
+}
+
+type lspTextDocument struct {
+	URI     string `json:"uri"`
+	Text    string `json:"text"`
+	Version int    `json:"version"`
+}
+
+type lspDocumentParams struct {
+	TextDocument   lspTextDocument `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+	Text *string `json:"text"`
+}
+
+type lspCodeActionParams struct {
+	TextDocument lspTextDocument `json:"textDocument"`
+	Range        lspRange        `json:"range"`
+}
+
+// languageServer implements the subset of the Language Server Protocol needed
+// to provide diagnostics and quick fixes.
+//
+// The server keeps a single `Linter` (and, therefore, a single
+// `check.Manager`) alive for its entire lifetime, which means that the
+// configuration pipeline only runs once -- or when the user saves one of the
+// loaded configuration files.
+type languageServer struct {
+	flags  *core.CLIFlags
+	linter *lint.Linter
+
+	// docs holds the latest content of each open document, keyed by URI.
+	docs map[string]string
+	// alerts holds the latest alerts for each open document, keyed by URI.
+	alerts map[string][]core.Alert
+
+	in       *bufio.Reader
+	out      io.Writer
+	shutdown bool
+}
+
+func newLanguageServer(flags *core.CLIFlags, in io.Reader, out io.Writer) *languageServer {
+	return &languageServer{
+		flags:  flags,
+		docs:   make(map[string]string),
+		alerts: make(map[string][]core.Alert),
+		in:     bufio.NewReader(in),
+		out:    out,
+	}
+}
+
+func runLanguageServer(_ []string, flags *core.CLIFlags) error {
+	server := newLanguageServer(flags, os.Stdin, os.Stdout)
+	return server.serve()
+}
+
+// serve processes messages until the client sends `exit` or closes the
+// connection.
+func (s *languageServer) serve() error {
+	for {
+		msg, err := s.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			s.reply(nil, nil, &lspError{Code: lspParseError, Message: err.Error()})
+			continue
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return errExitWithoutShutdown
+			}
+			return nil
+		}
+
+		result, rpcErr := s.handle(msg)
+		if msg.ID != nil {
+			s.reply(msg.ID, result, rpcErr)
+		}
+	}
+}
+
+func (s *languageServer) handle(msg lspMessage) (interface{}, *lspError) {
+	var err error
+
+	switch msg.Method {
+	case "initialize":
+		return s.initialize()
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen", "textDocument/didChange", "textDocument/didSave":
+		err = s.update(msg.Method, msg.Params)
+	case "textDocument/didClose":
+		err = s.close(msg.Params)
+	case "textDocument/codeAction":
+		return s.codeActions(msg.Params)
+	case "workspace/didChangeConfiguration":
+		err = s.reload()
+	default:
+		if msg.ID != nil {
+			return nil, &lspError{
+				Code:    lspMethodNotFound,
+				Message: fmt.Sprintf("method '%s' not supported", msg.Method),
+			}
+		}
+	}
+
+	if err != nil {
+		s.showError(err)
+		if msg.ID != nil {
+			return nil, &lspError{Code: lspInternalError, Message: core.StripANSI(err.Error())}
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *languageServer) initialize() (interface{}, *lspError) {
+	if err := s.reload(); err != nil {
+		return nil, &lspError{Code: lspInternalError, Message: core.StripANSI(err.Error())}
+	}
+
+	return map[string]interface{}{
+		"capabilities": map[string]interface{}{
+			"textDocumentSync": map[string]interface{}{
+				"openClose": true,
+				"change":    1, // Full
+				"save":      map[string]bool{"includeText": true},
+			},
+			"codeActionProvider": true,
+		},
+		"serverInfo": map[string]string{
+			"name":    "vale",
+			"version": version,
+		},
+	}, nil
+}
+
+// reload (re)runs the configuration pipeline and re-lints all open documents.
+func (s *languageServer) reload() error {
+	cfg, err := core.ReadPipeline(s.flags, false)
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+	s.linter = linter
+
+	for uri := range s.docs {
+		if err = s.publish(uri); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *languageServer) update(method string, raw json.RawMessage) error {
+	var params lspDocumentParams
+
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return err
+	}
+	uri := params.TextDocument.URI
+
+	switch method {
+	case "textDocument/didOpen":
+		s.docs[uri] = params.TextDocument.Text
+	case "textDocument/didChange":
+		// We only advertise full-document syncing, so the last change
+		// always holds the entire document.
+		if n := len(params.ContentChanges); n > 0 {
+			s.docs[uri] = params.ContentChanges[n-1].Text
+		}
+	case "textDocument/didSave":
+		if params.Text != nil {
+			s.docs[uri] = *params.Text
+		}
+		if s.isConfigFile(uri) {
+			return s.reload()
+		}
+	}
+
+	return s.publish(uri)
+}
+
+func (s *languageServer) close(raw json.RawMessage) error {
+	var params lspDocumentParams
+
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return err
+	}
+	uri := params.TextDocument.URI
+
+	delete(s.docs, uri)
+	delete(s.alerts, uri)
+
+	return s.notify("textDocument/publishDiagnostics", map[string]interface{}{
+		"uri":         uri,
+		"diagnostics": []lspDiagnostic{},
+	})
+}
+
+func (s *languageServer) isConfigFile(uri string) bool {
+	if s.linter == nil {
+		return false
+	}
+
+	path, err := uriToPath(uri)
+	if err != nil {
+		return false
+	}
+
+	for _, cfg := range s.linter.Manager.Config.ConfigFiles {
+		if system.AbsPath(cfg) == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// publish lints the given document and sends its diagnostics to the client.
+func (s *languageServer) publish(uri string) error {
+	text, ok := s.docs[uri]
+	if !ok || s.linter == nil {
+		return nil
+	}
+
+	path, err := uriToPath(uri)
+	if err != nil {
+		return err
+	}
+
+	linted, err := s.linter.LintStringWithPath(text, path)
+	if err != nil {
+		return err
+	}
+
+	alerts := []core.Alert{}
+	for _, f := range linted {
+		alerts = append(alerts, fileAlerts(f)...)
+	}
+	s.alerts[uri] = alerts
+
+	diagnostics := []lspDiagnostic{}
+	for _, a := range alerts {
+		diagnostics = append(diagnostics, toDiagnostic(text, a))
+	}
+
+	return s.notify("textDocument/publishDiagnostics", map[string]interface{}{
+		"uri":         uri,
+		"diagnostics": diagnostics,
+	})
+}
+
+// codeActions builds quick fixes for every alert in the requested range.
+func (s *languageServer) codeActions(raw json.RawMessage) (interface{}, *lspError) {
+	var params lspCodeActionParams
+
+	actions := []lspCodeAction{}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return actions, &lspError{Code: lspParseError, Message: err.Error()}
+	}
+
+	uri := params.TextDocument.URI
+	text := s.docs[uri]
+
+	for _, a := range s.alerts[uri] {
+		if a.Action.Name == "" {
+			continue
+		}
+
+		diagnostic := toDiagnostic(text, a)
+		if !rangesOverlap(diagnostic.Range, params.Range) {
+			continue
+		}
+
+		suggestions, err := check.FixAlert(a, s.linter.Manager.Config)
+		if err != nil {
+			// Not every action can be resolved (e.g., a missing script), but
+			// that shouldn't prevent the other fixes from being offered.
+			continue
+		}
+
+		for i, suggestion := range suggestions {
+			title := fmt.Sprintf("Replace with '%s'", suggestion)
+			if suggestion == "" {
+				title = fmt.Sprintf("Remove '%s'", a.Match)
+			}
+
+			actions = append(actions, lspCodeAction{
+				Title:       title,
+				Kind:        "quickfix",
+				Diagnostics: []lspDiagnostic{diagnostic},
+				IsPreferred: i == 0 && len(suggestions) == 1,
+				Edit: lspWorkspaceEdit{
+					Changes: map[string][]lspTextEdit{
+						uri: {{Range: diagnostic.Range, NewText: suggestion}},
+					},
+				},
+			})
+		}
+	}
+
+	return actions, nil
+}
+
+func (s *languageServer) showError(err error) {
+	_ = s.notify("window/showMessage", map[string]interface{}{
+		"type":    1, // Error
+		"message": core.StripANSI(err.Error()),
+	})
+}
+
+// read reads a single message using the LSP base protocol:
+//
+//	Content-Length: <n>\r\n
+//	\r\n
+//	<n bytes of JSON>
+func (s *languageServer) read() (lspMessage, error) {
+	var msg lspMessage
+
+	length := -1
+	for {
+		line, err := s.in.ReadString('\n')
+		if err != nil {
+			return msg, err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if found && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return msg, fmt.Errorf("invalid Content-Length: %w", err)
+			}
+		}
+	}
+
+	if length < 0 {
+		return msg, errors.New("missing Content-Length header")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.in, body); err != nil {
+		return msg, err
+	}
+
+	err := json.Unmarshal(body, &msg)
+	return msg, err
+}
+
+func (s *languageServer) write(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(b), b)
+	return err
+}
+
+func (s *languageServer) reply(id *json.RawMessage, result interface{}, rpcErr *lspError) {
+	_ = s.write(lspResponse{JSONRPC: "2.0", ID: id, Result: result, Error: rpcErr})
+}
+
+func (s *languageServer) notify(method string, params interface{}) error {
+	return s.write(lspNotification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func toDiagnostic(text string, a core.Alert) lspDiagnostic {
+	d := lspDiagnostic{
+		Range:    alertToRange(text, a),
+		Severity: levelToSeverity[a.Severity],
+		Code:     a.Check,
+		Source:   "vale",
+		Message:  a.Message,
+	}
+
+	if a.Link != "" {
+		d.CodeDescription = &lspCodeDescription{Href: a.Link}
+	}
+
+	return d
+}
+
+// alertToRange converts an Alert's location -- a 1-based line and an
+// inclusive, 1-based span of runes -- into an LSP range, which uses 0-based
+// lines and UTF-16 code units.
+func alertToRange(text string, a core.Alert) lspRange {
+	line := a.Line - 1
+	if line < 0 {
+		line = 0
+	}
+
+	content := ""
+	if lines := strings.Split(text, "\n"); line < len(lines) {
+		content = lines[line]
+	}
+
+	return lspRange{
+		Start: lspPosition{Line: line, Character: utf16Len(content, a.Span[0]-1)},
+		End:   lspPosition{Line: line, Character: utf16Len(content, a.Span[1])},
+	}
+}
+
+// utf16Len returns the number of UTF-16 code units in the first `n` runes of
+// `s`.
+//
+// Spans that extend past the end of the line (e.g., multi-line matches) are
+// clamped to the line's end.
+func utf16Len(s string, n int) int {
+	units := 0
+	for i, r := range []rune(s) {
+		if i >= n {
+			break
+		}
+		units += utf16RuneLen(r)
+	}
+	return units
+}
+
+func utf16RuneLen(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+func rangesOverlap(a, b lspRange) bool {
+	return !positionBefore(a.End, b.Start) && !positionBefore(b.End, a.Start)
+}
+
+func positionBefore(a, b lspPosition) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
+
+// uriToPath converts a `file://` URI into a local file path.
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	} else if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported URI scheme '%s'", u.Scheme)
+	}
+
+	path := u.Path
+	if system.IsWindows() {
+		// file:///C:/foo -> C:/foo
+		path = strings.TrimPrefix(path, "/")
+	}
+
+	return filepath.FromSlash(path), nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func lspFrame(t *testing.T, id int, method string, params interface{}) string {
+	msg := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+	if id > 0 {
+		msg["id"] = id
+	}
+
+	b, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(b), b)
+}
+
+func TestLanguageServer(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg := filepath.Join(dir, ".vale.ini")
+	err := os.WriteFile(cfg, []byte("[*.md]\nBasedOnStyles = Vale\n"), os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uri := "file://" + filepath.ToSlash(filepath.Join(dir, "test.md"))
+	doc := map[string]interface{}{"uri": uri, "text": "This is is a test.\n"}
+
+	var input strings.Builder
+	input.WriteString(lspFrame(t, 1, "initialize", map[string]interface{}{}))
+	input.WriteString(lspFrame(t, 0, "textDocument/didOpen", map[string]interface{}{
+		"textDocument": doc,
+	}))
+	input.WriteString(lspFrame(t, 2, "shutdown", nil))
+	input.WriteString(lspFrame(t, 0, "exit", nil))
+
+	var output bytes.Buffer
+
+	flags := &core.CLIFlags{Path: cfg, IgnoreGlobal: true}
+	server := newLanguageServer(flags, strings.NewReader(input.String()), &output)
+	if err = server.serve(); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newLanguageServer(flags, &output, nil)
+
+	var diagnostics []lspDiagnostic
+	for {
+		msg, rerr := client.read()
+		if rerr != nil {
+			break
+		} else if msg.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+
+		var params struct {
+			Diagnostics []lspDiagnostic `json:"diagnostics"`
+		}
+		if err = json.Unmarshal(msg.Params, &params); err != nil {
+			t.Fatal(err)
+		}
+		diagnostics = params.Diagnostics
+	}
+
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diagnostics))
+	}
+
+	d := diagnostics[0]
+	if d.Code != "Vale.Repetition" {
+		t.Fatalf("expected 'Vale.Repetition', got '%s'", d.Code)
+	}
+
+	expected := lspRange{
+		Start: lspPosition{Line: 0, Character: 5},
+		End:   lspPosition{Line: 0, Character: 10},
+	}
+	if d.Range != expected {
+		t.Fatalf("expected %v, got %v", expected, d.Range)
+	}
+}
+
+func TestUTF16Len(t *testing.T) {
+	if n := utf16Len("a😀b", 3); n != 4 {
+		t.Fatalf("expected 4, got %d", n)
+	}
+}
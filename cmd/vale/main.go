@@ -84,6 +84,8 @@ func handleError(err error) {
 
 func main() {
 	pflag.Parse()
+	Flags.Outputs = resolveOutputs(Flags.Outputs)
+	Flags.Output = primaryOutput(Flags.Outputs)
 
 	args := pflag.Args()
 	argc := len(args)
@@ -98,8 +100,11 @@ func main() {
 	}
 
 	if argc > 0 {
+		// NOTE: A `test` path takes precedence over the `test` command, since
+		// a project's `test` directory is a common thing to lint. Every other
+		// command always wins.
 		cmd, exists := Actions[args[0]]
-		if exists {
+		if exists && !(args[0] == "test" && system.FileExists(args[0])) {
 			if err := cmd(args[1:], &Flags); err != nil {
 				handleError(err)
 			}
@@ -117,15 +122,42 @@ func main() {
 		handleError(err)
 	}
 
-	linted, err := doLint(args, linter, Flags.Glob)
+	var linted []*core.File
+	if Flags.Diff != "" {
+		linted, err = doLintDiff(args, linter, &Flags)
+	} else {
+		linted, err = doLint(args, linter, Flags.Glob)
+	}
 	if err != nil {
 		handleError(err)
 	}
 
-	hasErrors, err := PrintAlerts(linted, config)
+	shown := linted
+	if config.Baseline != "" {
+		if shown, err = applyBaseline(linted, config, os.Stderr); err != nil {
+			handleError(err)
+		}
+	}
+
+	hasErrors, err := PrintAlerts(shown, linter.Manager)
 	if err != nil {
 		handleError(err)
-	} else if hasErrors && !Flags.NoExit {
+	}
+
+	if profile := linter.Profile(); profile != nil {
+		if err = printProfile(profile, Flags.Output, os.Stderr); err != nil {
+			handleError(err)
+		}
+	}
+
+	if Flags.Watch {
+		if err = runWatch(args, linter, linted); err != nil {
+			handleError(err)
+		}
+		os.Exit(0)
+	}
+
+	if hasErrors && !Flags.NoExit {
 		os.Exit(1)
 	}
 
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+type ruleTiming struct {
+	Rule         string
+	Milliseconds float64
+	Calls        int
+	Alerts       int
+}
+
+type formatTiming struct {
+	Format       string
+	Milliseconds float64
+	Files        int
+}
+
+// printProfile writes the linter's profile (see `--profile`) to `w` as a
+// table or, for `--output=JSON`, as JSON.
+func printProfile(p *lint.Profile, output string, w io.Writer) error {
+	report := p.Report()
+
+	rules := make([]ruleTiming, 0, len(report.Rules))
+	for _, r := range report.Rules {
+		rules = append(rules, ruleTiming{
+			Rule: r.Rule, Milliseconds: toMilliseconds(r.Time), Calls: r.Calls,
+			Alerts: r.Alerts})
+	}
+
+	formats := make([]formatTiming, 0, len(report.Formats))
+	for _, f := range report.Formats {
+		formats = append(formats, formatTiming{
+			Format: f.Format, Milliseconds: toMilliseconds(f.Time), Files: f.Files})
+	}
+
+	if output == "JSON" {
+		b, err := json.MarshalIndent(struct {
+			Rules   []ruleTiming
+			Formats []formatTiming
+		}{rules, formats}, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+
+	ruleData := pterm.TableData{{"Rule", "Time (ms)", "Calls", "Alerts"}}
+	for _, r := range rules {
+		ruleData = append(ruleData, []string{
+			r.Rule, formatMilliseconds(r.Milliseconds), strconv.Itoa(r.Calls),
+			strconv.Itoa(r.Alerts)})
+	}
+
+	formatData := pterm.TableData{{"Format", "Parse time (ms)", "Files"}}
+	for _, f := range formats {
+		formatData = append(formatData, []string{
+			f.Format, formatMilliseconds(f.Milliseconds), strconv.Itoa(f.Files)})
+	}
+
+	for _, data := range []pterm.TableData{ruleData, formatData} {
+		table, err := pterm.DefaultTable.WithHasHeader().WithData(data).Srender()
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintf(w, "\n%s\n", table); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func formatMilliseconds(ms float64) string {
+	return strconv.FormatFloat(ms, 'f', 2, 64)
+}
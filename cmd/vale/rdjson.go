@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+var levelToRDJSON = map[string]string{
+	"error":      "ERROR",
+	"warning":    "WARNING",
+	"suggestion": "INFO",
+}
+
+// rdjsonResult is reviewdog's Diagnostic Format (rdjson).
+//
+// See https://github.com/reviewdog/reviewdog/tree/master/proto/rdf.
+type rdjsonResult struct {
+	Source      rdjsonSource       `json:"source"`
+	Diagnostics []rdjsonDiagnostic `json:"diagnostics"`
+}
+
+type rdjsonSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type rdjsonDiagnostic struct {
+	Message     string             `json:"message"`
+	Location    rdjsonLocation     `json:"location"`
+	Severity    string             `json:"severity"`
+	Source      *rdjsonSource      `json:"source,omitempty"`
+	Code        rdjsonCode         `json:"code"`
+	Suggestions []rdjsonSuggestion `json:"suggestions,omitempty"`
+}
+
+type rdjsonLocation struct {
+	Path  string      `json:"path"`
+	Range rdjsonRange `json:"range"`
+}
+
+type rdjsonRange struct {
+	Start rdjsonPosition `json:"start"`
+	End   rdjsonPosition `json:"end"`
+}
+
+type rdjsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type rdjsonCode struct {
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+type rdjsonSuggestion struct {
+	Range rdjsonRange `json:"range"`
+	Text  string      `json:"text"`
+}
+
+var rdjsonVale = rdjsonSource{Name: "vale", URL: "https://vale.sh"}
+
+// PrintRDJSONAlerts prints Alerts in reviewdog's rdjson format.
+func PrintRDJSONAlerts(linted []*core.File, cfg *core.Config, w io.Writer) bool {
+	diagnostics, hasErrors := toRDJSON(linted, cfg)
+	fmt.Fprintln(w, getJSON(rdjsonResult{Source: rdjsonVale, Diagnostics: diagnostics}))
+	return hasErrors
+}
+
+// PrintRDJSONLAlerts prints Alerts in reviewdog's rdjsonl format: one
+// diagnostic per line.
+func PrintRDJSONLAlerts(linted []*core.File, cfg *core.Config, w io.Writer) bool {
+	diagnostics, hasErrors := toRDJSON(linted, cfg)
+	for _, d := range diagnostics {
+		d.Source = &rdjsonVale
+
+		b, err := json.Marshal(d)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintln(w, string(b))
+	}
+	return hasErrors
+}
+
+func toRDJSON(linted []*core.File, cfg *core.Config) ([]rdjsonDiagnostic, bool) {
+	alertCount := 0
+
+	diagnostics := []rdjsonDiagnostic{}
+	for _, f := range linted {
+		path := filepath.ToSlash(f.Path)
+		for _, a := range fileAlerts(f) {
+			if a.Severity == "error" {
+				alertCount++
+			}
+
+			severity, ok := levelToRDJSON[a.Severity]
+			if !ok {
+				severity = "WARNING"
+			}
+
+			// rdjson columns are byte offsets and its ranges are exclusive,
+			// while our spans are (inclusive) rune offsets.
+			loc := rdjsonRange{
+				Start: rdjsonPosition{Line: a.Line, Column: byteColumn(f, a.Line, a.Span[0])},
+				End:   rdjsonPosition{Line: a.Line, Column: byteColumn(f, a.Line, a.Span[1]+1)},
+			}
+
+			diagnostics = append(diagnostics, rdjsonDiagnostic{
+				Message:     a.Message,
+				Location:    rdjsonLocation{Path: path, Range: loc},
+				Severity:    severity,
+				Code:        rdjsonCode{Value: a.Check, URL: a.Link},
+				Suggestions: toRDJSONSuggestions(a, loc, cfg),
+			})
+		}
+	}
+
+	return diagnostics, alertCount != 0
+}
+
+func toRDJSONSuggestions(a core.Alert, loc rdjsonRange, cfg *core.Config) []rdjsonSuggestion {
+	suggestions := []rdjsonSuggestion{}
+	if a.Action.Name == "" {
+		return suggestions
+	}
+
+	fixes, err := check.FixAlert(a, cfg)
+	if err != nil {
+		return suggestions
+	}
+
+	for _, s := range fixes {
+		suggestions = append(suggestions, rdjsonSuggestion{Range: loc, Text: s})
+	}
+
+	return suggestions
+}
+
+// byteColumn converts a 1-based rune column on the given line of `f` into a
+// 1-based byte column.
+func byteColumn(f *core.File, line, col int) int {
+	if line < 1 || line > len(f.Lines) || col < 1 {
+		return col
+	}
+
+	runes := []rune(f.Lines[line-1])
+	if col-1 > len(runes) {
+		return col
+	}
+
+	return len(string(runes[:col-1])) + 1
+}
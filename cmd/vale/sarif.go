@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+var levelToSARIF = map[string]string{
+	"error":      "error",
+	"warning":    "warning",
+	"suggestion": "note",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string            `json:"name"`
+	InformationURI string            `json:"informationUri"`
+	Version        string            `json:"version"`
+	Rules          []sarifDescriptor `json:"rules"`
+}
+
+type sarifDescriptor struct {
+	ID                   string             `json:"id"`
+	ShortDescription     *sarifMessage      `json:"shortDescription,omitempty"`
+	HelpURI              string             `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex *int            `json:"ruleIndex,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifMessage `json:"insertedContent"`
+}
+
+// PrintSARIFAlerts prints Alerts as a SARIF 2.1.0 log.
+//
+// Every loaded rule is listed as a `reportingDescriptor`, regardless of
+// whether or not it produced any alerts.
+func PrintSARIFAlerts(linted []*core.File, mgr *check.Manager, w io.Writer) bool {
+	alertCount := 0
+
+	names := []string{}
+	for name := range mgr.Rules() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rules := []sarifDescriptor{}
+	ruleToIndex := map[string]int{}
+	for i, name := range names {
+		def := mgr.Rules()[name].Fields()
+
+		descriptor := sarifDescriptor{
+			ID:                   name,
+			HelpURI:              def.Link,
+			DefaultConfiguration: sarifConfiguration{Level: toSARIFLevel(def.Level)},
+		}
+		if def.Description != "" {
+			descriptor.ShortDescription = &sarifMessage{Text: def.Description}
+		}
+
+		rules = append(rules, descriptor)
+		ruleToIndex[name] = i
+	}
+
+	results := []sarifResult{}
+	for _, f := range linted {
+		artifact := sarifArtifactLocation{URI: toArtifactURI(f.Path)}
+		for _, a := range fileAlerts(f) {
+			if a.Severity == "error" {
+				alertCount++
+			}
+
+			region := sarifRegion{
+				StartLine:   a.Line,
+				StartColumn: a.Span[0],
+				// SARIF's `endColumn` is exclusive, while our spans are
+				// inclusive.
+				EndColumn: a.Span[1] + 1,
+			}
+
+			result := sarifResult{
+				RuleID:  a.Check,
+				Level:   toSARIFLevel(a.Severity),
+				Message: sarifMessage{Text: a.Message},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: artifact,
+						Region:           region,
+					},
+				}},
+				Fixes: toSARIFFixes(a, artifact, region, mgr.Config),
+			}
+			if idx, ok := ruleToIndex[a.Check]; ok {
+				result.RuleIndex = &idx
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	fmt.Fprintln(w, getJSON(sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "Vale",
+				InformationURI: "https://vale.sh",
+				Version:        version,
+				Rules:          rules,
+			}},
+			ColumnKind: "unicodeCodePoints",
+			Results:    results,
+		}},
+	}))
+
+	return alertCount != 0
+}
+
+func toSARIFFixes(a core.Alert, artifact sarifArtifactLocation, region sarifRegion, cfg *core.Config) []sarifFix {
+	fixes := []sarifFix{}
+	if a.Action.Name == "" {
+		return fixes
+	}
+
+	// Unresolvable actions (e.g., a missing script) are simply omitted: the
+	// result itself is still valid without a fix.
+	suggestions, err := check.FixAlert(a, cfg)
+	if err != nil {
+		return fixes
+	}
+
+	for _, s := range suggestions {
+		description := fmt.Sprintf("Replace with '%s'", s)
+		if s == "" {
+			description = fmt.Sprintf("Remove '%s'", a.Match)
+		}
+		fixes = append(fixes, sarifFix{
+			Description: sarifMessage{Text: description},
+			ArtifactChanges: []sarifArtifactChange{{
+				ArtifactLocation: artifact,
+				Replacements: []sarifReplacement{{
+					DeletedRegion:   region,
+					InsertedContent: sarifMessage{Text: s},
+				}},
+			}},
+		})
+	}
+
+	return fixes
+}
+
+func toSARIFLevel(level string) string {
+	if l, ok := levelToSARIF[level]; ok {
+		return l
+	}
+	return "warning"
+}
+
+// toArtifactURI converts a file path into a URI reference: relative paths are
+// kept as-is, while absolute paths become `file://` URIs.
+func toArtifactURI(path string) string {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+
+	uri := filepath.ToSlash(path)
+	if !strings.HasPrefix(uri, "/") {
+		// Windows: C:/foo -> /C:/foo
+		uri = "/" + uri
+	}
+
+	return "file://" + uri
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+func TestPrintSARIFAlerts(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		".vale.ini": "StylesPath = styles\nMinAlertLevel = suggestion\n\n[*.md]\nBasedOnStyles = Test\n",
+		"styles/Test/Error.yml": "extends: existence\nmessage: \"Don't use '%s'.\"\n" +
+			"level: error\ntokens:\n  - foo\n",
+		"styles/Test/Warning.yml": "extends: existence\nmessage: \"Avoid '%s'.\"\n" +
+			"level: warning\ntokens:\n  - bar\n",
+		"styles/Test/Swap.yml": "extends: substitution\nmessage: \"Use '%s' instead of '%s'.\"\n" +
+			"level: suggestion\naction:\n  name: replace\nswap:\n  baz: qux\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := core.ReadPipeline(&core.CLIFlags{
+		Path: filepath.Join(dir, ".vale.ini"), IgnoreGlobal: true}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE: "é" is two bytes but a single code point.
+	linted, err := linter.LintStringWithPath("Café foo, bar, and baz.\n", "test.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if hasErrors := PrintSARIFAlerts(linted, linter.Manager, &buf); !hasErrors {
+		t.Error("expected the error-level alert to be reported")
+	}
+
+	var log sarifLog
+	if err = json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+
+	results := log.Runs[0].Results
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	expected := []struct {
+		rule   string
+		level  string
+		region sarifRegion
+	}{
+		{"Test.Error", "error", sarifRegion{StartLine: 1, StartColumn: 6, EndColumn: 9}},
+		{"Test.Warning", "warning", sarifRegion{StartLine: 1, StartColumn: 11, EndColumn: 14}},
+		{"Test.Swap", "note", sarifRegion{StartLine: 1, StartColumn: 20, EndColumn: 23}},
+	}
+
+	for i, e := range expected {
+		r := results[i]
+		if r.RuleID != e.rule || r.Level != e.level {
+			t.Errorf("result %d: expected %s (%s), got %s (%s)", i, e.rule, e.level, r.RuleID, r.Level)
+		}
+
+		loc := r.Locations[0].PhysicalLocation
+		if loc.ArtifactLocation.URI != "test.md" {
+			t.Errorf("result %d: unexpected URI '%s'", i, loc.ArtifactLocation.URI)
+		}
+		if !reflect.DeepEqual(loc.Region, e.region) {
+			t.Errorf("result %d: expected %+v, got %+v", i, e.region, loc.Region)
+		}
+
+		if r.RuleIndex == nil || log.Runs[0].Tool.Driver.Rules[*r.RuleIndex].ID != e.rule {
+			t.Errorf("result %d: expected a rule index for %s", i, e.rule)
+		}
+	}
+
+	fixes := results[2].Fixes
+	if len(fixes) != 1 {
+		t.Fatalf("expected a single fix, got %d", len(fixes))
+	}
+
+	replacement := fixes[0].ArtifactChanges[0].Replacements[0]
+	if replacement.InsertedContent.Text != "qux" || replacement.DeletedRegion != expected[2].region {
+		t.Errorf("unexpected replacement: %+v", replacement)
+	}
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	gosync "sync"
+	"time"
+
+	"github.com/errata-ai/vale/v3/internal/check"
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+)
+
+// maxRequestSize is the largest request body the server accepts.
+const maxRequestSize = 10 << 20
+
+// lintRequest is the body of a `POST /lint` request.
+//
+// Either `text` or `path` is required: `text` is linted as if it were the
+// content of `path` (if given) or a file with the extension `ext`; `path`
+// on its own is read from disk, provided that it's inside of the directory
+// that contains the root configuration file.
+type lintRequest struct {
+	Text *string `json:"text"`
+	Ext  string  `json:"ext"`
+	Path string  `json:"path"`
+}
+
+// lintServer exposes a single, long-lived Linter over HTTP.
+type lintServer struct {
+	flags *core.CLIFlags
+
+	// mu guards linter, which isn't safe for concurrent use.
+	mu     gosync.Mutex
+	linter *lint.Linter
+
+	// root is the directory that `path` requests are restricted to, if any.
+	root string
+}
+
+func newLintServer(flags *core.CLIFlags) (*lintServer, error) {
+	cfg, err := core.ReadPipeline(flags, false)
+	if err != nil {
+		return nil, err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &lintServer{flags: flags, linter: linter}
+	if cfgRoot, rerr := cfg.Root(); rerr == nil {
+		server.root, err = filepath.EvalSymlinks(filepath.Dir(cfgRoot))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return server, nil
+}
+
+func runServer(args []string, flags *core.CLIFlags) error {
+	if len(args) != 0 {
+		return core.NewE100("serve", errors.New("no arguments expected"))
+	}
+
+	server, err := newLintServer(flags)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:              flags.Addr,
+		Handler:           server.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Fprintf(os.Stderr, "Listening on http://%s ...\n", flags.Addr)
+	return srv.ListenAndServe()
+}
+
+func (s *lintServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /lint", s.lint)
+	mux.HandleFunc("POST /fix", s.fix)
+	mux.HandleFunc("GET /rules", s.rules)
+	mux.HandleFunc("GET /config", s.config)
+	return checkHost(mux)
+}
+
+// checkHost rejects requests whose `Host` header isn't an IP address or
+// `localhost`.
+//
+// Since the server reads files from disk, this protects it from DNS rebinding
+// attacks, where a website that the user visits resolves its own domain to
+// the server's address.
+func checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		host = strings.Trim(host, "[]")
+
+		if host != "localhost" && net.ParseIP(host) == nil {
+			writeError(w, http.StatusForbidden, fmt.Errorf("unexpected host '%s'", r.Host))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// lint responds with the alerts for the requested text or file, in the same
+// form as `--output=JSON`.
+func (s *lintServer) lint(w http.ResponseWriter, r *http.Request) {
+	var req lintRequest
+
+	if err := decodeRequest(w, r, &req); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errNotJSON) {
+			status = http.StatusUnsupportedMediaType
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	text, path := "", req.Path
+	switch {
+	case req.Text != nil:
+		text = *req.Text
+		if path == "" {
+			ext := req.Ext
+			if ext == "" {
+				ext = s.flags.InExt
+			}
+			path = "stdin" + ext
+		}
+	case path != "":
+		b, err := s.readFile(path)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		text = string(b)
+	default:
+		writeError(w, http.StatusBadRequest, errors.New("'text' or 'path' is required"))
+		return
+	}
+
+	s.mu.Lock()
+	linted, err := s.linter.LintStringWithPath(text, path)
+	s.mu.Unlock()
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	formatted := map[string][]core.Alert{}
+	for _, f := range linted {
+		if alerts := fileAlerts(f); len(alerts) > 0 {
+			formatted[f.Path] = alerts
+		}
+	}
+
+	writeJSON(w, http.StatusOK, formatted)
+}
+
+// readFile reads the file at path, which must be inside of the server's root.
+func (s *lintServer) readFile(path string) ([]byte, error) {
+	if s.root == "" {
+		return nil, errors.New("'path' requires a configuration file")
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: We resolve symlinks so that they can't point outside of the root.
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return nil, err
+	} else if !isWithin(resolved, s.root) {
+		return nil, fmt.Errorf("'%s' is outside of '%s'", path, s.root)
+	}
+
+	return os.ReadFile(resolved)
+}
+
+// fix responds with the suggestions for the given alert, as in `vale fix`.
+func (s *lintServer) fix(w http.ResponseWriter, r *http.Request) {
+	if !isJSON(r) {
+		writeError(w, http.StatusUnsupportedMediaType, errNotJSON)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.mu.Lock()
+	resp, err := check.ParseAlert(string(body), s.linter.Manager.Config)
+	s.mu.Unlock()
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// rules responds with the definitions of all loaded rules, by name.
+func (s *lintServer) rules(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules := map[string]check.Definition{}
+	for name, rule := range s.linter.Manager.Rules() {
+		rules[name] = rule.Fields()
+	}
+
+	writeJSON(w, http.StatusOK, rules)
+}
+
+// config responds with the current configuration, as in `vale ls-config`.
+func (s *lintServer) config(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, s.linter.Manager.Config)
+}
+
+// errNotJSON is returned for requests that aren't `application/json`.
+//
+// NOTE: Requiring JSON means that browsers can't send requests without a
+// CORS preflight (unlike, e.g., `text/plain`).
+var errNotJSON = errors.New("expected 'Content-Type: application/json'")
+
+func isJSON(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	if !isJSON(r) {
+		return errNotJSON
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": core.StripANSI(err.Error())})
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg := filepath.Join(dir, ".vale.ini")
+	err := os.WriteFile(cfg, []byte("[*.md]\nBasedOnStyles = Vale\n"), os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := newLintServer(&core.CLIFlags{Path: cfg, IgnoreGlobal: true, InExt: ".txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(server.handler())
+	defer ts.Close()
+
+	body := `{"text": "This is is a test.", "ext": ".md"}`
+	resp, err := http.Post(ts.URL+"/lint", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	var linted map[string][]core.Alert
+	if err = json.NewDecoder(resp.Body).Decode(&linted); err != nil {
+		t.Fatal(err)
+	}
+
+	alerts := linted["stdin.md"]
+	if len(alerts) != 1 || alerts[0].Check != "Vale.Repetition" {
+		t.Fatalf("expected a single 'Vale.Repetition' alert, got %v", linted)
+	}
+
+	resp, err = http.Post(ts.URL+"/lint", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an empty request, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Get(ts.URL + "/rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var rules map[string]json.RawMessage
+	if err = json.NewDecoder(resp.Body).Decode(&rules); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := rules["Vale.Repetition"]; !ok {
+		t.Fatal("expected 'Vale.Repetition' in the loaded rules")
+	}
+}
+
+func TestServerRestrictions(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg := filepath.Join(dir, ".vale.ini")
+	err := os.WriteFile(cfg, []byte("[*.md]\nBasedOnStyles = Vale\n"), os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := filepath.Join(dir, "test.md")
+	if err = os.WriteFile(doc, []byte("This is is a test.\n"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	outside := filepath.Join(t.TempDir(), "secret.md")
+	if err = os.WriteFile(outside, []byte("Secret.\n"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := newLintServer(&core.CLIFlags{Path: cfg, IgnoreGlobal: true, InExt: ".txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(server.handler())
+	defer ts.Close()
+
+	encode := func(v interface{}) string {
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+
+	cases := []struct {
+		name   string
+		host   string
+		ctype  string
+		body   string
+		status int
+	}{
+		{"path", "", "application/json", encode(map[string]string{"path": doc}), http.StatusOK},
+		{"charset", "", "application/json; charset=utf-8", `{"text": "Text."}`, http.StatusOK},
+		{"text/plain", "", "text/plain", `{"text": "Text."}`, http.StatusUnsupportedMediaType},
+		{"outside", "", "application/json", encode(map[string]string{"path": outside}), http.StatusBadRequest},
+		{"localhost", "localhost:7777", "application/json", `{"text": "Text."}`, http.StatusOK},
+		{"rebinding", "attacker.example:7777", "application/json", `{"text": "Text."}`, http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, rerr := http.NewRequest(http.MethodPost, ts.URL+"/lint", strings.NewReader(tc.body))
+			if rerr != nil {
+				t.Fatal(rerr)
+			}
+			req.Header.Set("Content-Type", tc.ctype)
+			if tc.host != "" {
+				req.Host = tc.host
+			}
+
+			resp, rerr := http.DefaultClient.Do(req)
+			if rerr != nil {
+				t.Fatal(rerr)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.status {
+				t.Errorf("expected %d, got %d", tc.status, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+	"github.com/errata-ai/vale/v3/internal/system"
+)
+
+// reExpect matches an expected-alert annotation, such as
+//
+//	<!-- vale-expect: 5, 12 -->
+//
+// which declares that the rule under test reports alerts starting at columns
+// 5 and 12 of the preceding line.
+var reExpect = regexp.MustCompile(`vale-expect:\s*([\d,\s]+)`)
+
+type fixtureAlert struct {
+	line   int
+	column int
+}
+
+func (a fixtureAlert) String() string {
+	return fmt.Sprintf("%d:%d", a.line, a.column)
+}
+
+// testStyle runs the fixtures of the given style.
+//
+// Fixtures are stored in `<Style>/tests/<Rule>/`: files whose names start
+// with "valid" must not produce any alerts for `<Style>.<Rule>`, while all
+// other files must produce exactly the alerts declared by their
+// `vale-expect` annotations (or at least one alert, if there are none).
+func testStyle(args []string, _ *core.CLIFlags) error {
+	if len(args) != 1 {
+		return core.NewE100("test", errors.New("one argument expected"))
+	}
+	return runStyleTests(args[0], os.Stdout)
+}
+
+// runStyleTests runs the fixtures of the style stored in `path`, writing the
+// results to `w`.
+func runStyleTests(path string, w io.Writer) error {
+	styleDir := system.AbsPath(path)
+	if !system.IsDir(styleDir) {
+		return core.NewE100("test", fmt.Errorf("'%s' is not a directory", path))
+	}
+	style := filepath.Base(styleDir)
+
+	cfg, err := core.NewConfig(&core.CLIFlags{IgnoreGlobal: true})
+	if err != nil {
+		return err
+	}
+
+	src := fmt.Sprintf(
+		"StylesPath = %s\nMinAlertLevel = suggestion\n[*]\nBasedOnStyles = %s\n",
+		filepath.ToSlash(filepath.Dir(styleDir)), style)
+	if _, err = core.FromString(src, cfg, false); err != nil {
+		return err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	rules := []string{}
+	for name := range linter.Manager.Rules() {
+		if strings.HasPrefix(name, style+".") {
+			rules = append(rules, name)
+		}
+	}
+	sort.Strings(rules)
+
+	passed, failed, untested := 0, 0, 0
+	for _, rule := range rules {
+		dir := filepath.Join(styleDir, "tests", strings.TrimPrefix(rule, style+"."))
+
+		fixtures, _ := filepath.Glob(filepath.Join(dir, "*"))
+		if len(fixtures) == 0 {
+			untested++
+			continue
+		}
+
+		for _, fixture := range fixtures {
+			if system.IsDir(fixture) {
+				continue
+			}
+
+			problems, ferr := runFixture(linter, rule, fixture)
+			if ferr != nil {
+				return ferr
+			}
+
+			name := filepath.Base(fixture)
+			if len(problems) == 0 {
+				fmt.Fprintf(w, "PASS %s (%s)\n", rule, name)
+				passed++
+			} else {
+				fmt.Fprintf(w, "FAIL %s (%s)\n", rule, name)
+				for _, p := range problems {
+					fmt.Fprintf(w, "     %s\n", p)
+				}
+				failed++
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d passed, %d failed, %d %s without fixtures.\n",
+		passed, failed, untested, pluralize("rule", untested))
+
+	if failed > 0 {
+		return core.NewE100("test", fmt.Errorf(
+			"%d %s failed", failed, pluralize("fixture", failed)))
+	}
+
+	return nil
+}
+
+// runFixture lints a single fixture, returning a description of each
+// difference between the expected and actual alerts.
+func runFixture(linter *lint.Linter, rule, path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, core.NewE100("test", err)
+	}
+
+	text, expected, err := parseFixture(string(b))
+	if err != nil {
+		return nil, core.NewE201FromPosition(err.Error(), path, 1)
+	}
+
+	linted, err := linter.LintStringWithPath(text, path)
+	if err != nil {
+		return nil, err
+	}
+
+	actual := map[fixtureAlert]string{}
+	for _, f := range linted {
+		for _, a := range f.Alerts {
+			if a.Check == rule {
+				actual[fixtureAlert{a.Line, a.Span[0]}] = a.Message
+			}
+		}
+	}
+
+	problems := []string{}
+	if strings.HasPrefix(filepath.Base(path), "valid") {
+		for _, a := range sortedFixtureAlerts(actual) {
+			problems = append(problems, fmt.Sprintf("unexpected alert at %s: %s", a, actual[a]))
+		}
+		return problems, nil
+	}
+
+	if len(expected) == 0 {
+		if len(actual) == 0 {
+			problems = append(problems, "expected at least one alert, got none")
+		}
+		return problems, nil
+	}
+
+	for _, a := range sortedFixtureAlerts(expected) {
+		if _, found := actual[a]; !found {
+			problems = append(problems, fmt.Sprintf("missing alert at %s", a))
+		}
+	}
+	for _, a := range sortedFixtureAlerts(actual) {
+		if _, found := expected[a]; !found {
+			problems = append(problems, fmt.Sprintf("unexpected alert at %s: %s", a, actual[a]))
+		}
+	}
+
+	return problems, nil
+}
+
+// parseFixture removes all `vale-expect` annotations from `src`, returning
+// the remaining text and the alerts that the annotations declare.
+func parseFixture(src string) (string, map[fixtureAlert]string, error) {
+	expected := map[fixtureAlert]string{}
+
+	lines := []string{}
+	for _, line := range strings.SplitAfter(src, "\n") {
+		m := reExpect.FindStringSubmatch(line)
+		if m == nil {
+			lines = append(lines, line)
+			continue
+		} else if len(lines) == 0 {
+			return "", expected, errors.New("'vale-expect' must follow the line it refers to")
+		}
+
+		for _, col := range strings.Split(m[1], ",") {
+			if col = strings.TrimSpace(col); col == "" {
+				continue
+			}
+			n, err := strconv.Atoi(col)
+			if err != nil {
+				return "", expected, err
+			}
+			expected[fixtureAlert{len(lines), n}] = ""
+		}
+	}
+
+	return strings.Join(lines, ""), expected, nil
+}
+
+func sortedFixtureAlerts(alerts map[fixtureAlert]string) []fixtureAlert {
+	sorted := []fixtureAlert{}
+	for a := range alerts {
+		sorted = append(sorted, a)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].line != sorted[j].line {
+			return sorted[i].line < sorted[j].line
+		}
+		return sorted[i].column < sorted[j].column
+	})
+
+	return sorted
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseFixture(t *testing.T) {
+	src := "First line.\nSecond line.\n<!-- vale-expect: 1, 8 -->\nThird line.\n"
+
+	text, expected, err := parseFixture(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if text != "First line.\nSecond line.\nThird line.\n" {
+		t.Fatalf("unexpected text: %q", text)
+	}
+
+	for _, a := range []fixtureAlert{{2, 1}, {2, 8}} {
+		if _, found := expected[a]; !found {
+			t.Fatalf("expected an alert at %s", a)
+		}
+	}
+
+	if _, _, err = parseFixture("<!-- vale-expect: 1 -->\n"); err == nil {
+		t.Fatal("expected an error for a leading annotation")
+	}
+}
+
+func TestRunStyleTests(t *testing.T) {
+	style := filepath.Join("..", "..", "testdata", "fixtures", "styletest", "Demo")
+
+	var out bytes.Buffer
+	if err := runStyleTests(style, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out.String(), "2 passed, 0 failed") {
+		t.Errorf("unexpected results: %s", out.String())
+	}
+
+	// A copy of the style with a fixture that expects the wrong column.
+	dir := filepath.Join(t.TempDir(), "Demo")
+	tests := filepath.Join(dir, "tests", "Annotations")
+	if err := os.MkdirAll(tests, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	rule, err := os.ReadFile(filepath.Join(style, "Annotations.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		filepath.Join(dir, "Annotations.yml"): string(rule),
+		filepath.Join(tests, "bad.md"):        "TODO: one.\n<!-- vale-expect: 2 -->\n",
+	}
+	for path, content := range files {
+		if err = os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out.Reset()
+	if err = runStyleTests(dir, &out); err == nil {
+		t.Error("expected an error for a failing fixture")
+	}
+
+	for _, line := range []string{"FAIL Demo.Annotations (bad.md)", "missing alert at 1:2", "unexpected alert at 1:1"} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("expected %q in: %s", line, out.String())
+		}
+	}
+}
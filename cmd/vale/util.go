@@ -9,6 +9,7 @@ import (
 	"github.com/pterm/pterm"
 
 	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
 )
 
 // Response is returned after an action.
@@ -30,6 +31,22 @@ func pluralize(s string, n int) string {
 	return s
 }
 
+// location formats an alert's position as `line:column`, prefixed by its
+// cell (e.g., `cell_2:3:5`) in notebooks.
+func location(a core.Alert) string {
+	loc := fmt.Sprintf("%d:%d", a.Line, a.Span[0])
+	if a.Cell > 0 {
+		loc = fmt.Sprintf("cell_%d:%s", a.Cell, loc)
+	}
+	return loc
+}
+
+// fileAlerts returns f's sorted alerts located relative to the file itself,
+// for formats that have no notion of notebook cells (see `location`).
+func fileAlerts(f *core.File) []core.Alert {
+	return lint.LocateCells(f.Content, f.SortedAlerts())
+}
+
 func getJSON(data interface{}) string {
 	b, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
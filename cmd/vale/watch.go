@@ -0,0 +1,390 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+	"github.com/errata-ai/vale/v3/internal/system"
+)
+
+// watchDebounce is how long we wait for a burst of events (e.g., an editor
+// writing a temporary file and renaming it) to settle before re-linting.
+const watchDebounce = 150 * time.Millisecond
+
+// A watchSession keeps a Linter alive between changes to its inputs.
+//
+// All paths are absolute, which ensures that a directory that's watched for
+// multiple reasons (e.g., `.` as both an input and the location of
+// `.vale.ini`) is always reported the same way.
+type watchSession struct {
+	args    []string
+	linter  *lint.Linter
+	watcher *fsnotify.Watcher
+	watched map[string]bool
+
+	// The latest results, by absolute path.
+	results map[string]*core.File
+
+	// Changes to these paths require rebuilding the Linter: configuration
+	// files, templates, and anything on the StylesPath.
+	assets    map[string]bool
+	assetDirs []string
+}
+
+// runWatch re-lints `args` whenever they change, starting from the results of
+// an initial run.
+func runWatch(args []string, linter *lint.Linter, linted []*core.File) error {
+	if len(args) == 0 {
+		return core.NewE100("watch", errors.New("expected at least one path to watch"))
+	}
+
+	for _, arg := range args {
+		if !system.FileExists(arg) && !system.IsDir(arg) {
+			return core.NewE100("watch", fmt.Errorf("'%s' is not a file or directory", arg))
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return core.NewE100("watch", err)
+	}
+	defer watcher.Close()
+
+	s := &watchSession{
+		args:    args,
+		linter:  linter,
+		watcher: watcher,
+		watched: make(map[string]bool),
+		results: make(map[string]*core.File),
+	}
+	s.store(linted)
+
+	for _, arg := range args {
+		if system.IsDir(arg) {
+			s.watchTree(absPath(arg), true)
+		} else {
+			s.watch(filepath.Dir(absPath(arg)))
+		}
+	}
+	s.watchAssets()
+	s.status()
+
+	changed := map[string]bool{}
+
+	var settle <-chan time.Time
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			} else if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			name := absPath(event.Name)
+			if event.Has(fsnotify.Create) && system.IsDir(name) {
+				s.watchCreated(name)
+			}
+
+			changed[name] = true
+			settle = time.After(watchDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			ShowError(core.NewE100("watch", err), Flags.Output, os.Stderr)
+		case <-settle:
+			s.update(changed)
+			changed = map[string]bool{}
+		}
+	}
+}
+
+// A watchAction is what we do in response to a batch of changes.
+type watchAction int
+
+const (
+	// watchSkip ignores the changes, which don't affect any inputs.
+	watchSkip watchAction = iota
+	// watchRebuild rebuilds the Linter and re-lints every input.
+	watchRebuild
+	// watchRelintAll re-lints every input.
+	watchRelintAll
+	// watchRelint re-lints only the changed inputs.
+	watchRelint
+)
+
+// plan decides how to respond to `changed`, returning the changed inputs.
+func (s *watchSession) plan(changed map[string]bool, crossFile bool) (watchAction, []string) {
+	rebuild := false
+
+	inputs := []string{}
+	for name := range changed {
+		if s.isAsset(name) {
+			rebuild = true
+		} else if s.isInput(name) {
+			inputs = append(inputs, name)
+		}
+	}
+	sort.Strings(inputs)
+
+	switch {
+	case rebuild:
+		return watchRebuild, inputs
+	case len(inputs) == 0:
+		return watchSkip, inputs
+	case crossFile:
+		// A change to one file can affect the alerts of every other file.
+		return watchRelintAll, inputs
+	default:
+		return watchRelint, inputs
+	}
+}
+
+// update re-lints the files affected by `changed`, rebuilding the Linter
+// first if its configuration has changed.
+func (s *watchSession) update(changed map[string]bool) {
+	action, inputs := s.plan(changed, s.linter.Manager.HasCrossFile())
+	switch action {
+	case watchSkip:
+		return
+	case watchRebuild:
+		config, err := core.ReadPipeline(&Flags, false)
+		if err != nil {
+			ShowError(err, Flags.Output, os.Stderr)
+			return
+		}
+
+		linter, err := lint.NewLinter(config)
+		if err != nil {
+			ShowError(err, Flags.Output, os.Stderr)
+			return
+		}
+
+		s.linter = linter
+		s.watchAssets()
+		s.relintAll()
+	case watchRelintAll:
+		s.relintAll()
+	case watchRelint:
+		s.relint(inputs)
+	}
+
+	s.print()
+}
+
+func (s *watchSession) relintAll() {
+	s.results = make(map[string]*core.File)
+	s.relint(s.args)
+}
+
+func (s *watchSession) relint(paths []string) {
+	existing := []string{}
+	for _, path := range paths {
+		abs := absPath(path)
+		for key := range s.results {
+			if key == abs || isWithin(key, abs) {
+				delete(s.results, key)
+			}
+		}
+
+		if system.FileExists(path) || system.IsDir(path) {
+			existing = append(existing, s.display(abs))
+		}
+	}
+
+	if len(existing) == 0 {
+		return
+	}
+
+	linted, err := doLint(existing, s.linter, Flags.Glob)
+	if err != nil {
+		ShowError(err, Flags.Output, os.Stderr)
+		return
+	}
+	s.store(linted)
+}
+
+func (s *watchSession) store(linted []*core.File) {
+	for _, f := range linted {
+		s.results[absPath(f.Path)] = f
+	}
+}
+
+func (s *watchSession) print() {
+	files := make([]*core.File, 0, len(s.results))
+	for _, f := range s.results {
+		files = append(files, f)
+	}
+	sort.Sort(core.ByName(files))
+
+	if Flags.Output == "CLI" && isTerminal(os.Stdout) {
+		// Clear the screen so that only the latest results are shown.
+		fmt.Print("\033[H\033[2J")
+	}
+
+	// NOTE: We keep the unsuppressed results, since the baseline is applied
+	// to every file on each refresh.
+	cfg := s.linter.Manager.Config
+	if cfg.Baseline != "" {
+		var err error
+		if files, err = applyBaseline(files, cfg, os.Stderr); err != nil {
+			ShowError(err, Flags.Output, os.Stderr)
+			return
+		}
+	}
+
+	if _, err := PrintAlerts(files, s.linter.Manager); err != nil {
+		ShowError(err, Flags.Output, os.Stderr)
+	}
+	s.status()
+}
+
+func (s *watchSession) status() {
+	n := len(s.results)
+	fmt.Fprintf(os.Stderr, "\nWatching %d %s for changes (press Ctrl+C to stop) ...\n",
+		n, pluralize("file", n))
+}
+
+// watchAssets watches the configuration files, templates, and StylesPath of
+// the current Linter.
+func (s *watchSession) watchAssets() {
+	cfg := s.linter.Manager.Config
+
+	s.assets = make(map[string]bool)
+	s.assetDirs = []string{}
+
+	files := append([]string{}, cfg.ConfigFiles...)
+	for _, spec := range cfg.Flags.Outputs {
+		if sink := parseOutput(spec); system.FileExists(sink.style) {
+			files = append(files, sink.style)
+		}
+	}
+
+	for _, file := range files {
+		abs := absPath(file)
+		s.assets[abs] = true
+		// NOTE: We watch the parent directory since many editors save by
+		// replacing the file, which would end a watch on the file itself.
+		s.watch(filepath.Dir(abs))
+	}
+
+	for _, dir := range cfg.Paths {
+		if system.IsDir(dir) {
+			abs := absPath(dir)
+			s.assetDirs = append(s.assetDirs, abs)
+			s.watchTree(abs, false)
+		}
+	}
+}
+
+// watchCreated watches a directory that was created inside of an input, unless
+// it's one that the initial run would have skipped (e.g., `node_modules`).
+func (s *watchSession) watchCreated(dir string) {
+	if !s.isInput(dir) {
+		return
+	}
+
+	explicit := false
+	for _, arg := range s.args {
+		explicit = explicit || absPath(arg) == dir
+	}
+
+	if explicit || !core.ShouldIgnoreDirectory(filepath.Base(dir)) {
+		s.watchTree(dir, true)
+	}
+}
+
+// watchTree watches `root` and all of its subdirectories.
+//
+// `root` itself is always watched, while its ignored subdirectories are
+// skipped if `skipIgnored` is set.
+func (s *watchSession) watchTree(root string, skipIgnored bool) {
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil //nolint:nilerr
+		} else if skipIgnored && path != root && core.ShouldIgnoreDirectory(d.Name()) {
+			return filepath.SkipDir
+		}
+		s.watch(path)
+		return nil
+	})
+}
+
+func (s *watchSession) watch(dir string) {
+	if s.watched[dir] {
+		return
+	} else if err := s.watcher.Add(dir); err != nil {
+		ShowError(core.NewE100("watch", err), Flags.Output, os.Stderr)
+		return
+	}
+	s.watched[dir] = true
+}
+
+func (s *watchSession) isAsset(path string) bool {
+	if s.assets[path] {
+		return true
+	}
+	for _, dir := range s.assetDirs {
+		if isWithin(path, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *watchSession) isInput(path string) bool {
+	for _, arg := range s.args {
+		abs := absPath(arg)
+		if path == abs || isWithin(path, abs) {
+			return true
+		}
+	}
+	return false
+}
+
+// display converts an absolute path into the form it would have had in the
+// initial run (i.e., relative to the argument that contains it).
+func (s *watchSession) display(path string) string {
+	for _, arg := range s.args {
+		abs := absPath(arg)
+		if path == abs {
+			return arg
+		} else if rel, err := filepath.Rel(abs, path); err == nil && isWithin(path, abs) {
+			return filepath.Join(arg, rel)
+		}
+	}
+	return path
+}
+
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return abs
+}
+
+// isWithin reports whether `path` is inside of the directory `dir`.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == "." {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestIsWithin(t *testing.T) {
+	dir := filepath.Join("root", "docs")
+
+	cases := []struct {
+		path     string
+		expected bool
+	}{
+		{filepath.Join(dir, "a.md"), true},
+		{filepath.Join(dir, "sub", "a.md"), true},
+		{filepath.Join(dir, "..notes"), true},
+		{dir, false},
+		{filepath.Join("root", "docs2", "a.md"), false},
+		{filepath.Join("root", "a.md"), false},
+	}
+
+	for _, tc := range cases {
+		if got := isWithin(tc.path, dir); got != tc.expected {
+			t.Errorf("isWithin(%q, %q): expected %v, got %v", tc.path, dir, tc.expected, got)
+		}
+	}
+}
+
+func TestWatchSession(t *testing.T) {
+	s := &watchSession{
+		args:      []string{"docs", "README.md"},
+		assets:    map[string]bool{absPath(".vale.ini"): true},
+		assetDirs: []string{absPath("styles")},
+	}
+
+	doc := absPath(filepath.Join("docs", "a.md"))
+	readme := absPath("README.md")
+	other := absPath("other.md")
+	rule := absPath(filepath.Join("styles", "Vale", "Rule.yml"))
+
+	displayed := map[string]string{
+		doc:    filepath.Join("docs", "a.md"),
+		readme: "README.md",
+		other:  other,
+	}
+	for path, expected := range displayed {
+		if got := s.display(path); got != expected {
+			t.Errorf("display(%q): expected %q, got %q", path, expected, got)
+		}
+	}
+
+	for _, path := range []string{doc, readme, absPath("docs")} {
+		if !s.isInput(path) {
+			t.Errorf("expected %q to be an input", path)
+		}
+	}
+	if s.isInput(other) {
+		t.Errorf("expected %q not to be an input", other)
+	}
+
+	for _, path := range []string{absPath(".vale.ini"), rule} {
+		if !s.isAsset(path) {
+			t.Errorf("expected %q to be an asset", path)
+		}
+	}
+	if s.isAsset(doc) {
+		t.Errorf("expected %q not to be an asset", doc)
+	}
+
+	cases := []struct {
+		name      string
+		changed   []string
+		crossFile bool
+		action    watchAction
+		inputs    []string
+	}{
+		{"unrelated", []string{other}, false, watchSkip, []string{}},
+		{"input", []string{readme, other}, false, watchRelint, []string{readme}},
+		{"cross-file", []string{doc}, true, watchRelintAll, []string{doc}},
+		{"config", []string{doc, absPath(".vale.ini")}, false, watchRebuild, []string{doc}},
+		{"style", []string{rule}, true, watchRebuild, []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed := map[string]bool{}
+			for _, path := range tc.changed {
+				changed[path] = true
+			}
+
+			action, inputs := s.plan(changed, tc.crossFile)
+			if action != tc.action {
+				t.Errorf("expected action %d, got %d", tc.action, action)
+			}
+			if !reflect.DeepEqual(inputs, tc.inputs) {
+				t.Errorf("expected inputs %q, got %q", tc.inputs, inputs)
+			}
+		})
+	}
+}
+
+func TestWatchCreated(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, sub := range []string{"new/nested", "new/.git", "node_modules/pkg"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	s := &watchSession{args: []string{dir}, watcher: watcher, watched: map[string]bool{}}
+	s.watchCreated(filepath.Join(dir, "new"))
+	s.watchCreated(filepath.Join(dir, "node_modules"))
+	s.watchCreated(t.TempDir())
+
+	expected := map[string]bool{
+		filepath.Join(dir, "new"):           true,
+		filepath.Join(dir, "new", "nested"): true,
+	}
+	if !reflect.DeepEqual(s.watched, expected) {
+		t.Errorf("expected %v, got %v", expected, s.watched)
+	}
+}
+
+func TestWatchBaseline(t *testing.T) {
+	dir := t.TempDir()
+
+	results := []*core.File{{
+		Path:   filepath.Join(dir, "a.md"),
+		Lines:  []string{"A TODO."},
+		Alerts: []core.Alert{{Check: "Test.Todo", Line: 1, Match: "TODO"}},
+	}}
+
+	cfg := &core.Config{Baseline: filepath.Join(dir, ".vale-baseline.json")}
+	if err := core.NewBaseline(results, dir).Write(cfg.Baseline); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each refresh re-applies the baseline to the same results.
+	for i := 0; i < 2; i++ {
+		var stale bytes.Buffer
+
+		shown, err := applyBaseline(results, cfg, &stale)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(shown[0].Alerts) != 0 {
+			t.Errorf("refresh %d: expected the alert to be suppressed", i)
+		} else if stale.Len() != 0 {
+			t.Errorf("refresh %d: unexpected stale entries: %s", i, stale.String())
+		}
+
+		if len(results[0].Alerts) != 1 {
+			t.Fatalf("refresh %d: expected the results to keep their alerts", i)
+		}
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"strings"
 
 	"github.com/d5/tengo/v2"
-	"github.com/d5/tengo/v2/stdlib"
 	"github.com/jdkato/twine/strcase"
 
 	"github.com/errata-ai/vale/v3/internal/core"
@@ -87,10 +86,15 @@ func script(name string, alert core.Alert, cfg *core.Config) ([]string, error) {
 		return suggestions, err
 	}
 
+	modules, err := newModuleMap(cfg)
+	if err != nil {
+		return suggestions, err
+	}
+
 	script := tengo.NewScript(source)
-	script.SetImports(stdlib.GetModuleMap("text", "fmt", "math"))
+	script.SetImports(modules)
 
-	err = script.Add("match", alert.Match)
+	err = script.Add("match", "")
 	if err != nil {
 		return suggestions, err
 	}
@@ -100,7 +104,10 @@ func script(name string, alert core.Alert, cfg *core.Config) ([]string, error) {
 		return suggestions, err
 	}
 
-	if err = compiled.Run(); err != nil {
+	limits := Limits{timeout: defaultTimeout}
+	compiled, err = limits.run(compiled, map[string]interface{}{
+		"match": alert.Match})
+	if err != nil {
 		return suggestions, err
 	}
 
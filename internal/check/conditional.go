@@ -17,6 +17,9 @@ type Conditional struct {
 	exceptRe   *regexp2.Regexp
 	Ignorecase bool
 	Vocab      bool
+	// `crossfile` (`bool`): Accepts a definition (`second`) from any linted
+	// file, rather than only from earlier in the same file.
+	Crossfile bool
 }
 
 // NewConditional creates a new `conditional`-based rule.
@@ -70,6 +73,10 @@ func (c Conditional) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.
 	//
 	// In other words: if "WHO" exists, it must also have a definition -- which
 	// we're currently looking for.
+	if c.Crossfile {
+		return c.candidates(txt, cfg)
+	}
+
 	matches := c.patterns[0].FindAllStringSubmatch(txt, -1)
 	for _, mat := range matches {
 		if len(mat) > 1 {
@@ -105,6 +112,48 @@ func (c Conditional) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.
 	return alerts, nil
 }
 
+// candidates reports every definition and use, which are then compared
+// across files by `Manager.ResolveCrossFile`.
+//
+// Definitions are never reported themselves; they only serve to discard
+// the uses they define.
+func (c Conditional) candidates(txt string, cfg *core.Config) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	for _, submat := range c.patterns[0].FindAllStringSubmatchIndex(txt, -1) {
+		for idx := 2; idx+1 < len(submat); idx += 2 {
+			if submat[idx] == -1 || submat[idx] == submat[idx+1] {
+				continue
+			}
+
+			a, err := makeAlert(c.Definition, submat[idx:idx+2], txt, cfg)
+			if err != nil {
+				return alerts, err
+			}
+			a.CrossFile = &core.CrossFile{Group: c.Name, Variant: crossFileDefinition}
+			alerts = append(alerts, a)
+		}
+	}
+
+	for _, loc := range c.patterns[1].FindAllStringIndex(txt, -1) {
+		s, err := re2Loc(txt, loc)
+		if err != nil {
+			return alerts, err
+		} else if isMatch(c.exceptRe, s) {
+			continue
+		}
+
+		a, err := makeAlert(c.Definition, loc, txt, cfg)
+		if err != nil {
+			return alerts, err
+		}
+		a.CrossFile = &core.CrossFile{Group: c.Name, Variant: s}
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
 // Fields provides access to the internal rule definition.
 func (c Conditional) Fields() Definition {
 	return c.Definition
@@ -27,6 +27,9 @@ type Consistency struct {
 	Nonword bool
 	// `ignorecase` (`bool`): Makes all matches case-insensitive.
 	Ignorecase bool
+	// `crossfile` (`bool`): Enforces consistency across all linted files,
+	// rather than within each file, reporting the less common option.
+	Crossfile bool
 }
 
 // NewConsistency creates a new `consistency`-based rule.
@@ -86,6 +89,15 @@ func (o Consistency) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.
 
 	for _, s := range o.steps {
 		matches := s.pattern.FindAllStringSubmatchIndex(txt, -1)
+		if o.Crossfile {
+			found, err := o.candidates(s, matches, txt, cfg)
+			if err != nil {
+				return alerts, err
+			}
+			alerts = append(alerts, found...)
+			continue
+		}
+
 		for _, submat := range matches {
 			for idx, mat := range submat {
 				if mat != -1 && idx > 0 && idx%2 == 0 {
@@ -111,6 +123,34 @@ func (o Consistency) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.
 	return alerts, nil
 }
 
+// candidates reports every match of a step, which are then compared across
+// files by `Manager.ResolveCrossFile`.
+func (o Consistency) candidates(s step, matches [][]int, txt string, cfg *core.Config) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	o.Name = o.Extends
+	for _, submat := range matches {
+		for idx, mat := range submat {
+			if mat == -1 || idx == 0 || idx%2 != 0 {
+				continue
+			}
+
+			a, err := makeAlert(o.Definition, []int{mat, submat[idx+1]}, txt, cfg)
+			if err != nil {
+				return alerts, err
+			}
+
+			a.CrossFile = &core.CrossFile{
+				Group:   o.Extends + "." + s.subs[0],
+				Variant: s.pattern.SubexpNames()[idx/2],
+			}
+			alerts = append(alerts, a)
+		}
+	}
+
+	return alerts, nil
+}
+
 // Fields provides access to the internal rule definition.
 func (o Consistency) Fields() Definition {
 	return o.Definition
@@ -0,0 +1,139 @@
+package check
+
+import (
+	"sort"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// crossFileDefinition is the variant of a `conditional` rule's definitions
+// (the matches of `second`), as opposed to its uses.
+const crossFileDefinition = "\x00definition"
+
+type candidate struct {
+	file  *core.File
+	alert core.Alert
+}
+
+// ResolveCrossFile replaces the candidate alerts of `crossfile` rules with
+// the alerts that should actually be reported, based on the candidates found
+// in every file in `files`:
+//
+//   - For `consistency` rules, we report the uses of all but the most common
+//     option of each `either` pair (ties go to the first occurrence).
+//
+//   - For `conditional` rules, we report the uses that aren't defined
+//     anywhere.
+func (mgr *Manager) ResolveCrossFile(files []*core.File) {
+	sorted := make([]*core.File, 0, len(files))
+	for _, f := range files {
+		if f != nil {
+			sorted = append(sorted, f)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Path < sorted[j].Path
+	})
+
+	groups := map[string][]candidate{}
+	for _, f := range sorted {
+		kept := []core.Alert{}
+		for _, a := range f.SortedAlerts() {
+			if a.CrossFile == nil {
+				kept = append(kept, a)
+			} else {
+				groups[a.CrossFile.Group] = append(groups[a.CrossFile.Group], candidate{f, a})
+			}
+		}
+		f.Alerts = kept
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	reported := map[*core.File]map[string]int{}
+	for _, name := range names {
+		found := groups[name]
+
+		var report func(core.Alert) bool
+		if _, ok := mgr.rules[found[0].alert.Check].(Conditional); ok {
+			report = undefinedUses(found)
+		} else {
+			report = minorityUses(found)
+		}
+
+		for _, c := range found {
+			if !report(c.alert) {
+				continue
+			}
+
+			limit := 0
+			if rule, ok := mgr.rules[c.alert.Check]; ok {
+				limit = rule.Fields().Limit
+			}
+
+			if reported[c.file] == nil {
+				reported[c.file] = map[string]int{}
+			}
+			if limit > 0 && reported[c.file][c.alert.Check] >= limit {
+				continue
+			}
+			reported[c.file][c.alert.Check]++
+
+			c.alert.CrossFile = nil
+			c.file.Alerts = append(c.file.Alerts, c.alert)
+		}
+	}
+}
+
+func minorityUses(found []candidate) func(core.Alert) bool {
+	counts := map[string]int{}
+
+	preferred := ""
+	for _, c := range found {
+		variant := c.alert.CrossFile.Variant
+
+		counts[variant]++
+		if preferred == "" || counts[variant] > counts[preferred] {
+			preferred = variant
+		}
+	}
+
+	return func(a core.Alert) bool {
+		return a.CrossFile.Variant != preferred
+	}
+}
+
+func undefinedUses(found []candidate) func(core.Alert) bool {
+	defined := map[string]bool{}
+	for _, c := range found {
+		if c.alert.CrossFile.Variant == crossFileDefinition {
+			defined[c.alert.Match] = true
+		}
+	}
+
+	return func(a core.Alert) bool {
+		return a.CrossFile.Variant != crossFileDefinition && !defined[a.CrossFile.Variant]
+	}
+}
+
+// HasCrossFile reports whether any of the loaded rules are `crossfile` rules,
+// whose alerts depend on every linted file.
+func (mgr *Manager) HasCrossFile() bool {
+	for _, rule := range mgr.rules {
+		switch r := rule.(type) {
+		case Consistency:
+			if r.Crossfile {
+				return true
+			}
+		case Conditional:
+			if r.Crossfile {
+				return true
+			}
+		}
+	}
+	return false
+}
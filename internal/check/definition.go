@@ -49,6 +49,7 @@ var extensionPoints = []string{
 	"conditional",
 	"consistency",
 	"existence",
+	"headings",
 	"occurrence",
 	"repetition",
 	"substitution",
@@ -157,6 +158,8 @@ func buildRule(cfg *core.Config, generic baseCheck) (Rule, error) {
 		return NewMetric(cfg, generic, path)
 	case "script":
 		return NewScript(cfg, generic, path)
+	case "headings":
+		return NewHeadings(cfg, generic, path)
 	default:
 		return Existence{}, core.NewE201FromTarget(
 			fmt.Sprintf("'extends' key must be one of %v.", extensionPoints),
@@ -23,6 +23,9 @@ type Existence struct {
 	IgnoreCase bool
 	Nonword    bool
 	Vocab      bool
+	// `inflect` (`bool`): Also matches the English inflections of
+	// single-word tokens (e.g., "utilizes" and "utilized" for "utilize").
+	Inflect bool
 }
 
 // NewExistence creates a new `Rule` that extends `Existence`.
@@ -54,9 +57,12 @@ func NewExistence(cfg *core.Config, generic baseCheck, path string) (Existence,
 
 	parsed := []string{}
 	for _, token := range rule.Tokens {
-		if strings.TrimSpace(token) != "" {
-			parsed = append(parsed, token)
+		if strings.TrimSpace(token) == "" {
+			continue
+		} else if rule.Inflect {
+			token = inflectToken(token)
 		}
+		parsed = append(parsed, token)
 	}
 	regex = fmt.Sprintf(regex, strings.Join(parsed, "|"))
 
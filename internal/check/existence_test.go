@@ -45,6 +45,19 @@ func TestExistence(t *testing.T) {
 	}
 }
 
+func TestInflectToken(t *testing.T) {
+	if actual := inflectToken("utilize"); actual != `(?:utilizing|utilized|utilizes|utilize)` {
+		t.Errorf("unexpected pattern for 'utilize': %q", actual)
+	}
+
+	// Words other than verbs and nouns are left alone (e.g., "us" -> "uses").
+	for _, token := range []string{"us", "a", "it"} {
+		if actual := inflectToken(token); actual != token {
+			t.Errorf("expected %q to be unchanged, got %q", token, actual)
+		}
+	}
+}
+
 func FuzzExistenceInit(f *testing.F) {
 	f.Add("hello")
 	f.Fuzz(func(_ *testing.T, s string) {
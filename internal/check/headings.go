@@ -0,0 +1,153 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// Headings enforces the structure of a document's outline.
+type Headings struct {
+	Definition `mapstructure:",squash"`
+	// `increment` (`bool`): Disallows skipping heading levels (e.g., an h2
+	// followed by an h4).
+	Increment bool
+	// `single` (`bool`): Allows at most one h1.
+	Single bool
+	// `depth` (`int`): The deepest heading level allowed (e.g., `3` for h3).
+	Depth int
+	// `unique` (`bool`): Disallows sibling headings with the same text.
+	Unique bool
+	// `punctuation` (`string`): Characters that headings can't end with.
+	Punctuation string
+}
+
+// NewHeadings creates a new `headings`-based rule.
+func NewHeadings(_ *core.Config, generic baseCheck, path string) (Headings, error) {
+	rule := Headings{}
+
+	err := decodeRule(generic, &rule)
+	if err != nil {
+		return rule, readStructureError(err, path)
+	}
+
+	if rule.Depth < 0 || rule.Depth > 6 {
+		return rule, core.NewE201FromTarget(
+			"'depth' must be between 1 and 6.", "depth", path)
+	}
+
+	if rule.Message == "" {
+		rule.Message = "'%s' %s."
+	}
+
+	// The outline is only complete once the whole document has been read.
+	rule.Definition.Scope = []string{"summary"}
+
+	return rule, nil
+}
+
+// Run checks the file's outline, as determined by the markup walkers.
+func (h Headings) Run(_ nlp.Block, f *core.File, _ *core.Config) ([]core.Alert, error) {
+	alerts := []core.Alert{}
+
+	type sibling struct {
+		parent int
+		level  int
+		text   string
+	}
+
+	seen := map[sibling]bool{}
+	h1s := 0
+
+	for i, heading := range f.Headings {
+		problems := []string{}
+
+		if heading.Level == 1 {
+			h1s++
+			if h.Single && h1s > 1 {
+				problems = append(problems, "is an additional h1")
+			}
+		}
+
+		if h.Increment && i > 0 {
+			prev := f.Headings[i-1].Level
+			if heading.Level > prev+1 {
+				problems = append(problems, fmt.Sprintf(
+					"skips from h%d to h%d", prev, heading.Level))
+			}
+		}
+
+		if h.Depth > 0 && heading.Level > h.Depth {
+			problems = append(problems, fmt.Sprintf(
+				"is deeper than h%d", h.Depth))
+		}
+
+		if h.Unique {
+			key := sibling{parent: parentOf(f.Headings, i), level: heading.Level,
+				text: strings.ToLower(heading.Text)}
+			if seen[key] {
+				problems = append(problems, "duplicates a sibling heading")
+			}
+			seen[key] = true
+		}
+
+		if last, _ := utf8.DecodeLastRuneInString(heading.Text); h.Punctuation != "" &&
+			strings.ContainsRune(h.Punctuation, last) {
+			problems = append(problems, fmt.Sprintf("ends with '%c'", last))
+		}
+
+		if len(problems) > 0 {
+			// We report all of a heading's problems in a single alert since
+			// they share a location.
+			a := core.Alert{
+				Check:    h.Name,
+				Severity: h.Level,
+				Link:     h.Link,
+				Match:    heading.Text,
+				Line:     heading.Line,
+				Span: []int{
+					heading.Column,
+					heading.Column + utf8.RuneCountInString(heading.Text) - 1},
+			}
+			a.Message, a.Description = formatMessages(
+				h.Message, h.Description, heading.Text, joinProblems(problems))
+			alerts = append(alerts, a)
+		}
+	}
+
+	return alerts, nil
+}
+
+// Fields provides access to the internal rule definition.
+func (h Headings) Fields() Definition {
+	return h.Definition
+}
+
+// Pattern is the internal regex pattern used by this rule.
+func (h Headings) Pattern() string {
+	return ""
+}
+
+// parentOf returns the index of the heading that contains the i-th heading,
+// or -1 for top-level headings.
+func parentOf(headings []core.Heading, i int) int {
+	for j := i - 1; j >= 0; j-- {
+		if headings[j].Level < headings[i].Level {
+			return j
+		}
+	}
+	return -1
+}
+
+// joinProblems lists problems as a sentence fragment: "a", "a and b", or "a,
+// b, and c".
+func joinProblems(problems []string) string {
+	n := len(problems)
+	if n <= 2 {
+		return strings.Join(problems, " and ")
+	}
+	return strings.Join(problems[:n-1], ", ") + ", and " + problems[n-1]
+}
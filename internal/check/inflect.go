@@ -0,0 +1,118 @@
+package check
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// inflectToken converts a single-word token into a pattern that matches any
+// of its inflections. Other tokens (e.g., regular expressions or pronouns;
+// see `nlp.CanInflect`) are returned unchanged.
+func inflectToken(token string) string {
+	if !nlp.CanInflect(token) {
+		return token
+	}
+
+	forms := []string{token}
+	for form := range nlp.Inflections(token) {
+		forms = append(forms, form)
+	}
+
+	// Prefer the longest match (e.g., "utilizes" over "utilize").
+	sort.Slice(forms, func(i, j int) bool {
+		if len(forms[i]) != len(forms[j]) {
+			return len(forms[i]) > len(forms[j])
+		}
+		return forms[i] < forms[j]
+	})
+
+	return `(?:` + strings.Join(forms, "|") + `)`
+}
+
+// inflectSwap adds the inflections of each single-word verb or noun key in
+// `swap`, mapped to the matching inflection of its replacement (e.g.,
+// "utilized: used" for "utilize: use").
+//
+// Some forms are ambiguous (e.g., "commenced" is both the past tense and past
+// participle of "commence", but "begin" has distinct forms). These forms are
+// returned, mapped to their replacement for each POS tag, so they can be
+// resolved once we know the form's tag.
+func inflectSwap(swap map[string]string) map[string]map[string]string {
+	ambiguous := map[string]map[string]string{}
+	generated := map[string]string{}
+
+	// NOTE: We visit the keys in order so that, if two keys share a form
+	// (e.g., "read" and "reads"), the result doesn't depend on map order.
+	keys := make([]string, 0, len(swap))
+	for key := range swap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !nlp.CanInflect(key) {
+			continue
+		}
+		replacement := swap[key]
+
+		for form, tags := range nlp.Inflections(key) {
+			if _, found := swap[form]; found {
+				// An explicit entry takes precedence.
+				continue
+			} else if _, found = generated[form]; found {
+				continue
+			}
+
+			byTag := map[string]string{}
+			for _, pos := range tags {
+				byTag[pos] = inflectReplacement(replacement, pos)
+			}
+
+			generated[form] = byTag[tags[0]]
+			for _, pos := range tags[1:] {
+				if byTag[pos] != generated[form] {
+					ambiguous[form] = byTag
+					break
+				}
+			}
+		}
+	}
+
+	for form, replacement := range generated {
+		swap[form] = replacement
+	}
+
+	return ambiguous
+}
+
+// inflectReplacement inflects each of the options in a replacement (e.g.,
+// "use|employ").
+func inflectReplacement(replacement, pos string) string {
+	if strings.Contains(replacement, "$") {
+		// The replacement refers to capture groups.
+		return replacement
+	}
+
+	options := getOptions(replacement)
+	for i, option := range options {
+		options[i] = nlp.Inflect(option, pos)
+	}
+
+	return strings.Join(options, "|")
+}
+
+// tagOf returns the POS tag of the n-th occurrence of `word` in `txt`.
+func tagOf(txt, word string, n int, f *core.File) string {
+	for _, tok := range nlp.TextToTokens(txt, &f.NLP) {
+		if tok.Text != word {
+			continue
+		} else if n == 0 {
+			return tok.Tag
+		}
+		n--
+	}
+	return ""
+}
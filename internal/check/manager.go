@@ -127,6 +127,10 @@ func (mgr *Manager) addStyle(path string) error {
 		if err != nil {
 			return err
 		} else if info.IsDir() {
+			if fp != path && info.Name() == "tests" {
+				// Rule fixtures (see `vale test`).
+				return filepath.SkipDir
+			}
 			return nil
 		}
 		return mgr.addRuleFromSource(info.Name(), fp)
@@ -1,7 +1,6 @@
 package check
 
 import (
-	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -14,9 +13,13 @@ import (
 )
 
 var boilerplate = `math := import("math"); __res__ := (%s)`
+
+// variables are the metrics that a formula can use.
 var variables = []string{
-	"pre", "list", "blockquote", "heading_h1", "heading_h2", "heading_h3",
-	"heading_h4", "heading_h5", "heading_h6"}
+	"words", "sentences", "characters", "syllables", "polysyllabic_words",
+	"complex_words", "long_words", "paragraphs", "pre", "list", "blockquote",
+	"heading_h1", "heading_h2", "heading_h3", "heading_h4", "heading_h5",
+	"heading_h6"}
 var headings = regexp.MustCompile(`heading\.(h[1-6])`)
 
 // Metric implements arbitrary, readability-like formulas.
@@ -27,8 +30,10 @@ type Metric struct {
 	// Variables: # of words, # of sentences, etc.
 	Formula   string
 	Condition string
+	Limits    `mapstructure:",squash"`
 
-	path string
+	path     string
+	compiled *tengo.Compiled
 }
 
 // NewMetric creates a new `metric`-based rule.
@@ -44,13 +49,27 @@ func NewMetric(_ *core.Config, generic baseCheck, path string) (Metric, error) {
 	rule.Definition.Scope = []string{"summary"}
 	rule.Formula = headings.ReplaceAllString(rule.Formula, "heading_$1")
 
+	if err = rule.Limits.init(path); err != nil {
+		return rule, err
+	}
+
+	// We compile the formula on its own first so that we can tell whether an
+	// error is in the formula or the condition.
+	if _, err = rule.compile(""); err != nil {
+		return rule, core.NewE201FromTarget(err.Error(), "formula", path)
+	}
+
+	rule.compiled, err = rule.compile(rule.Condition)
+	if err != nil {
+		return rule, core.NewE201FromTarget(err.Error(), "condition", path)
+	}
+
 	return rule, nil
 }
 
 // Run calculates the readability level of the given text.
 func (o Metric) Run(_ nlp.Block, f *core.File, _ *core.Config) ([]core.Alert, error) {
 	alerts := []core.Alert{}
-	ctx := context.Background()
 
 	parameters, err := f.ComputeMetrics()
 	if err != nil {
@@ -68,23 +87,23 @@ func (o Metric) Run(_ nlp.Block, f *core.File, _ *core.Config) ([]core.Alert, er
 		}
 	}
 
-	// The actual result of our formula.
-	//
-	// We need this to allow showing the result in a rule's message.
-	res, err := evalMath(ctx, o.Formula, parameters)
+	compiled, err := o.Limits.run(o.compiled, parameters)
 	if err != nil {
 		return alerts, core.NewE201FromTarget(err.Error(), "formula", o.path)
 	}
 
-	// The binary result of our formula:
-	eqb := fmt.Sprintf("%f %s", res, o.Condition)
+	// The actual result of our formula.
+	//
+	// We need this to allow showing the result in a rule's message.
+	res := compiled.Get("__res__").Float()
 
-	match, err := evalMath(ctx, eqb, parameters)
-	if err != nil {
-		return alerts, core.NewE201FromTarget(err.Error(), "condition", o.path)
+	match, ok := compiled.Get("__match__").Value().(bool)
+	if !ok {
+		return alerts, core.NewE201FromTarget(
+			"condition must be a comparison (e.g., '> 10')", "condition", o.path)
 	}
 
-	if match.(bool) {
+	if match {
 		a := core.Alert{Check: o.Name, Severity: o.Level, Span: []int{1, 1},
 			Link: o.Link}
 		a.Message, a.Description = formatMessages(
@@ -105,30 +124,36 @@ func (o Metric) Pattern() string {
 	return o.Formula
 }
 
-func evalMath(
-	ctx context.Context,
-	expr string,
-	params map[string]interface{},
-) (interface{}, error) {
-	expr = strings.TrimSpace(expr)
-	if expr == "" {
+// compile compiles the rule's formula, and its condition (if any).
+//
+// Every metric is declared as a variable (defaulting to 0.0) since we don't
+// know which ones a file will have until it's linted. Any other identifier is
+// a compile error.
+func (o Metric) compile(condition string) (*tengo.Compiled, error) {
+	formula := strings.TrimSpace(o.Formula)
+	if formula == "" {
 		return nil, fmt.Errorf("empty expression")
 	}
 
-	script := tengo.NewScript([]byte(fmt.Sprintf(boilerplate, expr)))
+	src := fmt.Sprintf(boilerplate, formula)
+	if condition = strings.TrimSpace(condition); condition != "" {
+		src += fmt.Sprintf("; __match__ := __res__ %s", condition)
+	}
+
+	script := tengo.NewScript([]byte(src))
 	script.SetImports(stdlib.GetModuleMap("math"))
+	o.Limits.apply(script)
 
-	for pk, pv := range params {
-		err := script.Add(pk, pv)
-		if err != nil {
+	for _, name := range variables {
+		if err := script.Add(name, 0.0); err != nil {
 			return nil, fmt.Errorf("script add: %w", err)
 		}
 	}
 
-	compiled, err := script.RunContext(ctx)
+	compiled, err := script.Compile()
 	if err != nil {
-		return nil, fmt.Errorf("script run: %w", err)
+		return nil, fmt.Errorf("script compile: %w", err)
 	}
 
-	return compiled.Get("__res__").Value(), nil
+	return compiled, nil
 }
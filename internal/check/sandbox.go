@@ -0,0 +1,181 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/d5/tengo/v2"
+	"github.com/d5/tengo/v2/stdlib"
+	"github.com/errata-ai/regexp2"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// defaultTimeout is how long a Tengo-based rule can run on a single block (or
+// file, for `metric`) if it doesn't specify its own `timeout`.
+const defaultTimeout = 10 * time.Second
+
+// scriptModules are the standard library modules available to scripts.
+//
+// NOTE: We intentionally leave out `os` (see #495) and `rand`: scripts should
+// be read-only and deterministic. `times.sleep` is also replaced (see
+// `newModuleMap`).
+var scriptModules = []string{
+	"text", "fmt", "math", "json", "times", "enum", "base64", "hex"}
+
+// Limits are the resource limits of a Tengo-based rule (`script` or
+// `metric`).
+type Limits struct {
+	// `timeout` (`string`): The longest a single run can take (e.g., `500ms`
+	// or `2s`). Defaults to 10s.
+	Timeout string
+	// `maxallocs` (`int`): The most objects a single run can allocate.
+	// Defaults to no limit.
+	MaxAllocs int
+
+	timeout time.Duration
+}
+
+func (l *Limits) init(path string) error {
+	l.timeout = defaultTimeout
+	if l.Timeout != "" {
+		d, err := time.ParseDuration(l.Timeout)
+		if err != nil || d <= 0 {
+			return core.NewE201FromTarget(
+				"'timeout' must be a positive duration (e.g., '2s').",
+				l.Timeout,
+				path)
+		}
+		l.timeout = d
+	}
+
+	if l.MaxAllocs < 0 {
+		return core.NewE201FromTarget(
+			"'maxallocs' must be a positive number.", "maxallocs", path)
+	}
+
+	return nil
+}
+
+// apply sets the allocation limit on an uncompiled script.
+func (l *Limits) apply(script *tengo.Script) {
+	if l.MaxAllocs > 0 {
+		script.SetMaxAllocs(int64(l.MaxAllocs))
+	}
+}
+
+// run executes a copy of `compiled`, after setting the given variables,
+// within the rule's time limit.
+func (l *Limits) run(compiled *tengo.Compiled, vars map[string]interface{}) (*tengo.Compiled, error) {
+	clone := compiled.Clone()
+	for name, value := range vars {
+		if !clone.IsDefined(name) {
+			continue
+		} else if err := clone.Set(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+
+	err := clone.RunContext(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("script exceeded its timeout (%s)", l.timeout)
+	} else if errors.Is(err, tengo.ErrObjectAllocLimit) {
+		return nil, fmt.Errorf("script exceeded its allocation limit (%d)", l.MaxAllocs)
+	}
+
+	return clone, err
+}
+
+// newModuleMap returns the modules that scripts can import: our vetted subset
+// of the standard library and the `vale` module.
+//
+// The `vale` module provides `accepted(word)` and `rejected(word)`, which
+// report whether `word` is in the project's vocabulary.
+func newModuleMap(cfg *core.Config) (*tengo.ModuleMap, error) {
+	modules := stdlib.GetModuleMap(scriptModules...)
+
+	// NOTE: `times.sleep` blocks in Go, where it can't be interrupted, so a
+	// sleeping script would outlive its timeout.
+	times := map[string]tengo.Object{}
+	for name, attr := range stdlib.BuiltinModules["times"] {
+		times[name] = attr
+	}
+	times["sleep"] = &tengo.UserFunction{
+		Name: "sleep",
+		Value: func(...tengo.Object) (tengo.Object, error) {
+			return nil, errors.New("times.sleep is not available to rules")
+		},
+	}
+	modules.AddBuiltinModule("times", times)
+
+	if cfg == nil {
+		cfg = &core.Config{}
+	}
+
+	accepted, err := vocabLookup("accepted", cfg.AcceptedTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	rejected, err := vocabLookup("rejected", cfg.RejectedTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	modules.AddBuiltinModule("vale", map[string]tengo.Object{
+		"accepted": accepted,
+		"rejected": rejected,
+	})
+
+	return modules, nil
+}
+
+// vocabLookup creates a script function that reports whether its argument
+// matches one of `terms` in full.
+func vocabLookup(name string, terms []string) (*tengo.UserFunction, error) {
+	var re *regexp2.Regexp
+
+	if len(terms) > 0 {
+		// NOTE: As with our `Vale.Terms` rule, we need to add `(?-i)` to each
+		// term so that one `(?i)` doesn't apply to all of them.
+		options := make([]string, len(terms))
+		for i, term := range terms {
+			if !strings.HasPrefix(term, "(?i)") {
+				term = "(?-i)" + term
+			}
+			options[i] = "(?:" + term + ")"
+		}
+
+		compiled, err := regexp2.CompileStd(`^(?:` + strings.Join(options, "|") + `)$`)
+		if err != nil {
+			return nil, core.NewE100("vocabLookup", err)
+		}
+		re = compiled
+	}
+
+	return &tengo.UserFunction{
+		Name: name,
+		Value: func(args ...tengo.Object) (tengo.Object, error) {
+			if len(args) != 1 {
+				return nil, tengo.ErrWrongNumArguments
+			}
+
+			word, ok := tengo.ToString(args[0])
+			if !ok {
+				return nil, tengo.ErrInvalidArgumentType{
+					Name: "word", Expected: "string", Found: args[0].TypeName()}
+			}
+
+			if isMatch(re, word) {
+				return tengo.TrueValue, nil
+			}
+			return tengo.FalseValue, nil
+		},
+	}, nil
+}
@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/d5/tengo/v2"
-	"github.com/d5/tengo/v2/stdlib"
 
 	"github.com/errata-ai/vale/v3/internal/core"
 	"github.com/errata-ai/vale/v3/internal/nlp"
@@ -13,12 +12,39 @@ import (
 
 // Script is Tango-based script.
 //
+// In addition to `scope` (the text being linted), scripts have access to
+// `vale_context`, which provides:
+//
+//   - `block`: the block's `scope`, `parent`, and `context`.
+//   - `file`: the linted file's `path` and `ext`.
+//   - `sentences()`: the block's sentences.
+//   - `tokens()`: the block's words.
+//   - `tagged()`: the block's words with their part-of-speech `tag`.
+//
+// NOTE: These live in a single, namespaced variable (rather than, e.g., the
+// `vale` module, which is compiled into the script) so that they don't
+// conflict with the names that existing scripts declare.
+//
+// Sentences and tokens are maps with `text`, `begin`, and `end` keys, where
+// `begin` and `end` are byte offsets into `scope` (or -1 if the text couldn't
+// be located). They're only computed if the script calls their function.
+//
+// Each match can set an `action` (e.g., `{name: "replace", params: ["x"]}`)
+// to override the rule's own.
+//
+// Scripts can import a subset of Tengo's standard library (`text`, `fmt`,
+// `math`, `json`, `times`, `enum`, `base64`, and `hex`) and the `vale` module
+// (see `newModuleMap`). They're compiled once and each run is subject to the
+// rule's `Limits`.
+//
 // see https://github.com/d5/tengo.
 type Script struct {
 	Definition `mapstructure:",squash"`
+	Limits     `mapstructure:",squash"`
 	Script     string
 
-	path string
+	path     string
+	compiled *tengo.Compiled
 }
 
 // NewScript creates a new `script`-based rule.
@@ -42,35 +68,50 @@ func NewScript(cfg *core.Config, generic baseCheck, path string) (Script, error)
 		rule.Script = string(b)
 	}
 
+	if err = rule.Limits.init(path); err != nil {
+		return rule, err
+	}
+
+	modules, err := newModuleMap(cfg)
+	if err != nil {
+		return rule, err
+	}
+
+	script := tengo.NewScript([]byte(rule.Script))
+	script.SetImports(modules)
+	rule.Limits.apply(script)
+
+	// The variables are placeholders: their actual values are set before
+	// each run.
+	vars := scriptContext(nlp.Block{}, nil)
+	vars["scope"] = ""
+	for name, value := range vars {
+		if err = script.Add(name, value); err != nil {
+			return rule, core.NewE201FromTarget(err.Error(), "script", path)
+		}
+	}
+
+	rule.compiled, err = script.Compile()
+	if err != nil {
+		return rule, core.NewE201FromTarget(err.Error(), "script", path)
+	}
+
 	rule.path = path
 	return rule, nil
 }
 
 // Run executes the given script and returns its Alerts.
-func (s Script) Run(blk nlp.Block, _ *core.File, _ *core.Config) ([]core.Alert, error) {
+func (s Script) Run(blk nlp.Block, f *core.File, _ *core.Config) ([]core.Alert, error) {
 	var alerts []core.Alert
 
-	script := tengo.NewScript([]byte(s.Script))
-	// NOTE: We don't want to enable the`os` module because of the security
-	// implications?
-	//
-	// See #495, for example.
-	script.SetImports(stdlib.GetModuleMap("text", "fmt", "math"))
-
-	err := script.Add("scope", blk.Text)
-	if err != nil {
-		return alerts, core.NewE201FromTarget(err.Error(), "script", s.path)
-	}
+	vars := scriptContext(blk, f)
+	vars["scope"] = blk.Text
 
-	compiled, err := script.Compile()
+	compiled, err := s.Limits.run(s.compiled, vars)
 	if err != nil {
 		return alerts, core.NewE201FromTarget(err.Error(), "script", s.path)
 	}
 
-	if err = compiled.Run(); err != nil {
-		return alerts, core.NewE201FromTarget(err.Error(), "script", s.path)
-	}
-
 	for _, match := range parseMatches(compiled.Get("matches").Array()) {
 		matchText := blk.Text[match["begin"].(int):match["end"].(int)]
 		matchLoc := []int{match["begin"].(int), match["end"].(int)}
@@ -85,6 +126,10 @@ func (s Script) Run(blk nlp.Block, _ *core.File, _ *core.Config) ([]core.Alert,
 			Match:    matchText,
 			Action:   s.Action}
 
+		if action, ok := match["action"].(core.Action); ok {
+			a.Action = action
+		}
+
 		if matchMsg, ok := match["message"].(string); ok {
 			a.Message, a.Description = formatMessages(matchMsg, s.Description, matchText)
 		} else {
@@ -110,11 +155,123 @@ func parseMatches(a []interface{}) []map[string]interface{} {
 			match["message"] = msg
 		}
 
+		if action, ok := m["action"].(map[string]interface{}); ok {
+			match["action"] = parseAction(action)
+		}
+
 		matches = append(matches, match)
 	}
 	return matches
 }
 
+// scriptContext returns the (read-only) variables available to scripts, in
+// addition to `scope`.
+func scriptContext(blk nlp.Block, f *core.File) map[string]interface{} {
+	return map[string]interface{}{
+		"vale_context": &tengo.ImmutableMap{Value: contextObjects(blk, f)},
+	}
+}
+
+// contextObjects returns the contents of a script's `vale_context`.
+func contextObjects(blk nlp.Block, f *core.File) map[string]tengo.Object {
+	path, ext := "", ""
+	if f != nil {
+		path, ext = f.Path, f.RealExt
+	}
+
+	var sentences, words, tagged []interface{}
+
+	return map[string]tengo.Object{
+		"block": &tengo.ImmutableMap{Value: map[string]tengo.Object{
+			"scope":   &tengo.String{Value: blk.Scope},
+			"parent":  &tengo.String{Value: blk.Parent},
+			"context": &tengo.String{Value: blk.Context},
+		}},
+		"file": &tengo.ImmutableMap{Value: map[string]tengo.Object{
+			"path": &tengo.String{Value: path},
+			"ext":  &tengo.String{Value: ext},
+		}},
+		"sentences": lazyArray("sentences", func() []interface{} {
+			if sentences == nil {
+				sentences = locate(blk.Text, nlp.SentenceTokenizer.Segment(blk.Text), nil)
+			}
+			return sentences
+		}),
+		"tokens": lazyArray("tokens", func() []interface{} {
+			if words == nil {
+				words = locate(blk.Text, nlp.WordTokenizer.Tokenize(blk.Text), nil)
+			}
+			return words
+		}),
+		"tagged": lazyArray("tagged", func() []interface{} {
+			if tagged == nil {
+				var info *nlp.Info
+				if f != nil {
+					info = &f.NLP
+				}
+
+				texts, tags := []string{}, []string{}
+				for _, tok := range nlp.TextToTokens(blk.Text, info) {
+					texts = append(texts, tok.Text)
+					tags = append(tags, tok.Tag)
+				}
+				tagged = locate(blk.Text, texts, tags)
+			}
+			return tagged
+		}),
+	}
+}
+
+// lazyArray creates a script function that returns the result of `compute`.
+func lazyArray(name string, compute func() []interface{}) *tengo.UserFunction {
+	return &tengo.UserFunction{
+		Name: name,
+		Value: func(args ...tengo.Object) (tengo.Object, error) {
+			if len(args) != 0 {
+				return nil, tengo.ErrWrongNumArguments
+			}
+			return tengo.FromInterface(compute())
+		},
+	}
+}
+
+// locate finds the byte offsets of each segment of `text`, in order.
+func locate(text string, segments, tags []string) []interface{} {
+	located := []interface{}{}
+
+	cursor := 0
+	for i, seg := range segments {
+		begin, end := -1, -1
+		if idx := strings.Index(text[cursor:], seg); idx >= 0 && seg != "" {
+			begin = cursor + idx
+			end = begin + len(seg)
+			cursor = end
+		}
+
+		entry := map[string]interface{}{"text": seg, "begin": begin, "end": end}
+		if tags != nil {
+			entry["tag"] = tags[i]
+		}
+		located = append(located, entry)
+	}
+
+	return located
+}
+
+func parseAction(m map[string]interface{}) core.Action {
+	action := core.Action{}
+	action.Name, _ = m["name"].(string)
+
+	params, _ := m["params"].([]interface{})
+	for _, p := range params {
+		if param, ok := p.(string); ok {
+			action.Params = append(action.Params, param)
+		}
+	}
+
+	return action
+}
+
 // Fields provides access to the internal rule definition.
 func (s Script) Fields() Definition {
 	return s.Definition
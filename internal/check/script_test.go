@@ -0,0 +1,146 @@
+package check
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+func runScript(t *testing.T, cfg *core.Config, def baseCheck, text string) ([]core.Alert, error) {
+	rule, err := NewScript(cfg, def, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := core.NewFile("", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rule.Run(nlp.NewBlock("", text, "text"), file, cfg)
+}
+
+func TestScriptLimits(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = runScript(t, cfg, baseCheck{
+		"script":  "matches := []\nfor {}",
+		"timeout": "50ms",
+	}, "text")
+	if err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Errorf("expected a timeout error, got '%v'", err)
+	}
+
+	start := time.Now()
+	_, err = runScript(t, cfg, baseCheck{
+		"script":  "times := import(\"times\")\nmatches := []\ntimes.sleep(times.hour)",
+		"timeout": "50ms",
+	}, "text")
+	if err == nil {
+		t.Error("expected an error for a sleeping script")
+	} else if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected a sleeping script to be stopped, but it ran for %s", elapsed)
+	}
+
+	_, err = runScript(t, cfg, baseCheck{
+		"script":    "matches := []\nfor i := 0; i < 100; i++ { matches = append(matches, {}) }",
+		"maxallocs": 10,
+	}, "text")
+	if err == nil || !strings.Contains(err.Error(), "allocation") {
+		t.Errorf("expected an allocation error, got '%v'", err)
+	}
+
+	_, err = NewScript(cfg, baseCheck{"script": "matches := []", "timeout": "2"}, "")
+	if err == nil {
+		t.Error("expected an error for a timeout without a unit")
+	}
+}
+
+func TestScriptModules(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.AcceptedTokens = []string{"Vale", "(?i)MDX"}
+
+	script := `
+vale := import("vale")
+json := import("json")
+text := import("text")
+
+matches := []
+for word in text.fields(scope) {
+	if vale.accepted(word) {
+		i := text.index(scope, word)
+		matches = append(matches, {begin: i, end: i + len(word), message: string(json.encode(word))})
+	}
+}`
+
+	alerts, err := runScript(t, cfg, baseCheck{"script": script}, "vale Vale mdx Valerie")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := []string{}
+	for _, a := range alerts {
+		found = append(found, a.Message)
+	}
+
+	if strings.Join(found, " ") != `"Vale" "mdx"` {
+		t.Errorf("unexpected matches: %v", found)
+	}
+}
+
+func TestScriptContext(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Scripts can declare names of their own (e.g., `tokens`) without
+	// conflicting with the context.
+	script := `
+text := import("text")
+
+tokens := text.fields(scope)
+file := vale_context.file
+block := vale_context.block
+
+matches := []
+for s in vale_context.sentences() {
+	matches = append(matches, {begin: s.begin, end: s.end, message: block.scope + ":" + len(tokens)})
+}`
+
+	alerts, err := runScript(t, cfg, baseCheck{"script": script}, "One two. Three.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(alerts) != 2 || alerts[1].Match != "Three." || alerts[0].Message != "text:3" {
+		t.Errorf("unexpected matches: %v", alerts)
+	}
+}
+
+func TestMetricVariables(t *testing.T) {
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, formula := range []string{"words / sentences", "math.sqrt(heading.h2) + len([1])"} {
+		if _, err = NewMetric(cfg, baseCheck{"formula": formula, "condition": "> 1"}, ""); err != nil {
+			t.Errorf("expected '%s' to compile, got '%v'", formula, err)
+		}
+	}
+
+	_, err = NewMetric(cfg, baseCheck{"formula": "wrods / sentences", "condition": "> 1"}, "")
+	if err == nil {
+		t.Error("expected an error for an unknown variable")
+	}
+}
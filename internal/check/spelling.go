@@ -173,8 +173,20 @@ func (s Spelling) Run(blk nlp.Block, _ *core.File, _ *core.Config) ([]core.Alert
 	// See https://github.com/errata-ai/vale/v2/issues/148.
 	txt = s.gs.Convert(txt)
 
+	// Words are located in order so that repeated misspellings (and words
+	// that also appear inside other words) are reported where they occur.
+	cursor := 0
+
 OUTER:
 	for _, word := range nlp.WordTokenizer.Tokenize(txt) {
+		offset := strings.Index(txt[cursor:], word)
+		if offset >= 0 {
+			offset += cursor
+			cursor = offset + len(word)
+		} else {
+			offset = strings.Index(txt, word)
+		}
+
 		for _, filter := range s.Filters {
 			if filter.MatchString(word) {
 				continue OUTER
@@ -182,7 +194,6 @@ OUTER:
 		}
 
 		if !s.gs.Spell(word) && !isMatch(s.exceptRe, word) {
-			offset := strings.Index(txt, word)
 			loc := []int{offset, offset + len(word)}
 
 			a := core.Alert{Check: s.Name, Severity: s.Level, Span: loc,
@@ -24,6 +24,14 @@ type Substitution struct {
 	Nonword    bool
 	Vocab      bool
 	Capitalize bool
+	// `inflect` (`bool`): Also matches the English inflections of
+	// single-word keys, suggesting the same inflection of their
+	// replacements (e.g., "utilized" -> "used").
+	Inflect bool
+
+	// ambiguous maps inflected forms to their replacement for each POS tag
+	// (see `inflectSwap`).
+	ambiguous map[string]map[string]string
 
 	msgMap []string
 	// Deprecated
@@ -57,6 +65,10 @@ func NewSubstitution(cfg *core.Config, generic baseCheck, path string) (Substitu
 		func() bool { return !rule.Nonword },
 		func() string { return "" }, true)
 
+	if rule.Inflect {
+		rule.ambiguous = inflectSwap(rule.Swap)
+	}
+
 	terms := maps.Keys(rule.Swap)
 	sort.Slice(terms, func(p, q int) bool {
 		return len(terms[p]) > len(terms[q])
@@ -93,7 +105,7 @@ func NewSubstitution(cfg *core.Config, generic baseCheck, path string) (Substitu
 // Run executes the `substitution`-based rule.
 //
 // The rule looks for one pattern and then suggests a replacement.
-func (s Substitution) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core.Alert, error) {
+func (s Substitution) Run(blk nlp.Block, f *core.File, cfg *core.Config) ([]core.Alert, error) {
 	var alerts []core.Alert
 
 	txt := blk.Text
@@ -119,6 +131,19 @@ func (s Substitution) Run(blk nlp.Block, _ *core.File, cfg *core.Config) ([]core
 					return alerts, msgErr
 				}
 
+				if forms, ok := s.ambiguous[s.msgMap[(idx/2)-1]]; ok {
+					// The replacement depends on how the form is used (e.g.,
+					// "commenced" -> "began" or "begun").
+					before := string([]rune(txt)[:mat])
+					pos := tagOf(txt, observed, strings.Count(before, observed), f)
+					if form, found := forms[pos]; found {
+						expected = form
+						if s.Capitalize && observed == core.CapFirst(observed) {
+							expected = core.CapFirst(expected)
+						}
+					}
+				}
+
 				same := matchToken(expected, observed, false)
 				if !same && !isMatch(s.exceptRe, observed) {
 					action := s.Fields().Action
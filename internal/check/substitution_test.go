@@ -153,3 +153,79 @@ func TestOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestInflect(t *testing.T) {
+	swap := map[string]interface{}{
+		"extends": "substitution",
+		"name":    "Test.Inflect",
+		"level":   "error",
+		"message": "Use '%s' instead of '%s'.",
+		"scope":   "text",
+		"inflect": true,
+		"swap": map[string]string{
+			"utilize":  "use",
+			"commence": "begin",
+		},
+	}
+
+	rule, err := makeSubstitution(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "She utilized it after it had commenced, but he commenced first while utilizing it."
+	alerts, err := rule.Run(nlp.NewBlock(text, text, "text"), &core.File{}, &core.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"Use 'used' instead of 'utilized'.",
+		"Use 'begun' instead of 'commenced'.",
+		"Use 'began' instead of 'commenced'.",
+		"Use 'using' instead of 'utilizing'.",
+	}
+	if len(alerts) != len(expected) {
+		t.Fatalf("expected %d alerts, found %v", len(expected), alerts)
+	}
+
+	for i, a := range alerts {
+		if a.Message != expected[i] {
+			t.Errorf("expected %q, found %q", expected[i], a.Message)
+		}
+	}
+}
+
+func TestInflectSwap(t *testing.T) {
+	expected := map[string]string{
+		"utilize":    "use",
+		"utilizes":   "uses",
+		"utilized":   "used",
+		"utilizing":  "using",
+		"commence":   "begin",
+		"commences":  "begins",
+		"commenced":  "began",
+		"commencing": "beginning",
+	}
+
+	// Inflected forms must only be generated from the original keys, never
+	// from each other (e.g., "utilizeds").
+	for i := 0; i < 10; i++ {
+		swap := map[string]string{"utilize": "use", "commence": "begin"}
+
+		ambiguous := inflectSwap(swap)
+		if len(swap) != len(expected) {
+			t.Fatalf("expected %d entries, found %v", len(expected), swap)
+		}
+
+		for key, value := range expected {
+			if swap[key] != value {
+				t.Errorf("expected %q for %q, found %q", value, key, swap[key])
+			}
+		}
+
+		if ambiguous["commenced"]["VBN"] != "begun" {
+			t.Errorf("expected 'commenced' to be ambiguous, found %v", ambiguous)
+		}
+	}
+}
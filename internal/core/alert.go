@@ -28,8 +28,19 @@ type Alert struct {
 	Severity    string   // 'suggestion', 'warning', or 'error'
 	Match       string   // the actual matched text
 	Line        int      // the source line
-	Limit       int      `json:"-"` // the max times to report
-	Hide        bool     `json:"-"` // should we hide this alert?
+	Cell        int      `json:",omitempty"` // the notebook cell (1-based) that Line is relative to
+	Limit       int      `json:"-"`          // the max times to report
+	Hide        bool     `json:"-"`          // should we hide this alert?
+
+	// CrossFile is only set on the candidate alerts of `crossfile` rules,
+	// which are resolved once every file has been linted.
+	CrossFile *CrossFile `json:",omitempty"`
+}
+
+// A CrossFile identifies a candidate alert of a `crossfile` rule.
+type CrossFile struct {
+	Group   string // the set of alerts to compare -- e.g, an `either` pair
+	Variant string // the usage this alert represents within its group
 }
 
 // FormatAlert ensures that all required fields have data.
@@ -40,11 +51,14 @@ func FormatAlert(a *Alert, limit int, level, name string) {
 	if a.Check == "" {
 		a.Check = name
 	}
-	a.Limit = limit
+	if a.CrossFile == nil {
+		// Limits are applied once a `crossfile` rule has been resolved.
+		a.Limit = limit
+	}
 	a.Message = WhitespaceToSpace(a.Message)
 }
 
-// ByPosition sorts Alerts by line and column.
+// ByPosition sorts Alerts by notebook cell, line, and column.
 type ByPosition []Alert
 
 func (a ByPosition) Len() int      { return len(a) }
@@ -52,6 +66,9 @@ func (a ByPosition) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a ByPosition) Less(i, j int) bool {
 	ai, aj := a[i], a[j]
 
+	if ai.Cell != aj.Cell {
+		return ai.Cell < aj.Cell
+	}
 	if ai.Line != aj.Line {
 		return ai.Line < aj.Line
 	}
@@ -0,0 +1,187 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/system"
+)
+
+// BaselineVersion is the version of the baseline file format.
+const BaselineVersion = 1
+
+// A Baseline is a set of known alerts that should not be reported.
+//
+// Alerts are identified by a fingerprint rather than by their location, which
+// allows a baseline to survive unrelated edits (e.g., lines being added
+// above an alert).
+type Baseline struct {
+	Version int             `json:"version"`
+	Entries []BaselineEntry `json:"entries"`
+}
+
+// A BaselineEntry represents one or more identical alerts in a single file.
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Check       string `json:"check"`
+	Path        string `json:"path"`
+	Match       string `json:"match"`
+	Count       int    `json:"count"`
+}
+
+// NewBaseline creates a Baseline from all of the alerts in `files`.
+//
+// File paths are stored relative to `root`, which should be the directory
+// containing the baseline file.
+func NewBaseline(files []*File, root string) *Baseline {
+	entries := map[string]*BaselineEntry{}
+	for _, f := range files {
+		for _, e := range fileEntries(f, root) {
+			if found, ok := entries[e.Fingerprint]; ok {
+				found.Count++
+			} else {
+				entry := e
+				entries[e.Fingerprint] = &entry
+			}
+		}
+	}
+
+	baseline := Baseline{Version: BaselineVersion, Entries: []BaselineEntry{}}
+	for _, e := range entries {
+		baseline.Entries = append(baseline.Entries, *e)
+	}
+
+	sort.Slice(baseline.Entries, func(i, j int) bool {
+		a, b := baseline.Entries[i], baseline.Entries[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		} else if a.Check != b.Check {
+			return a.Check < b.Check
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+
+	return &baseline
+}
+
+// ReadBaseline loads the baseline file located at `path`.
+func ReadBaseline(path string) (*Baseline, error) {
+	var baseline Baseline
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, NewE100("baseline", err)
+	}
+
+	if err = json.Unmarshal(b, &baseline); err != nil {
+		return nil, NewE100("baseline", err)
+	}
+
+	return &baseline, nil
+}
+
+// Write saves the baseline to `path`.
+func (b *Baseline) Write(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return NewE100("baseline", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// Suppress removes all alerts in `files` that are covered by the baseline.
+//
+// It returns the entries (or portions of entries) for these files that no
+// longer match any alert. Entries for other files are never stale, since
+// they may simply not have been linted (e.g., `vale docs/a.md`).
+func (b *Baseline) Suppress(files []*File, root string) []BaselineEntry {
+	remaining := map[string]int{}
+	for _, e := range b.Entries {
+		remaining[e.Fingerprint] += e.Count
+	}
+
+	linted := map[string]bool{}
+	for _, f := range files {
+		linted[baselinePath(f, root)] = true
+
+		fingerprints := fileEntries(f, root)
+
+		alerts := []Alert{}
+		for i, a := range f.Alerts {
+			fp := fingerprints[i].Fingerprint
+			if remaining[fp] > 0 {
+				remaining[fp]--
+				continue
+			}
+			alerts = append(alerts, a)
+		}
+		f.Alerts = alerts
+	}
+
+	stale := []BaselineEntry{}
+	for _, e := range b.Entries {
+		if n := remaining[e.Fingerprint]; n > 0 && linted[e.Path] {
+			e.Count = min(n, e.Count)
+			remaining[e.Fingerprint] -= e.Count
+			stale = append(stale, e)
+		}
+	}
+
+	return stale
+}
+
+// fileEntries computes a (single-count) entry for each of f's alerts, in
+// order.
+func fileEntries(f *File, root string) []BaselineEntry {
+	path := baselinePath(f, root)
+
+	// NOTE: Some formats update `f.Lines` while linting, so we prefer to use
+	// the file as it exists on disk.
+	lines := f.Lines
+	if system.FileExists(f.Path) {
+		if b, err := os.ReadFile(f.Path); err == nil {
+			lines = strings.Split(Sanitize(string(b)), "\n")
+		}
+	}
+
+	entries := make([]BaselineEntry, 0, len(f.Alerts))
+	for _, a := range f.Alerts {
+		context := ""
+		if a.Line > 0 && a.Line <= len(lines) {
+			context = normalizeSpace(lines[a.Line-1])
+		}
+		match := normalizeSpace(a.Match)
+
+		sum := sha256.Sum256([]byte(strings.Join(
+			[]string{a.Check, path, match, context}, "\x00")))
+
+		entries = append(entries, BaselineEntry{
+			Fingerprint: hex.EncodeToString(sum[:]),
+			Check:       a.Check,
+			Path:        path,
+			Match:       match,
+			Count:       1,
+		})
+	}
+
+	return entries
+}
+
+// baselinePath returns f's path as it's stored in a baseline: relative to
+// `root`, with slashes.
+func baselinePath(f *File, root string) string {
+	path := f.Path
+	if rel, err := filepath.Rel(root, system.AbsPath(f.Path)); err == nil {
+		path = rel
+	}
+	return filepath.ToSlash(path)
+}
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
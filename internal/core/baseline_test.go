@@ -0,0 +1,47 @@
+package core
+
+import "testing"
+
+func TestBaselineLineShift(t *testing.T) {
+	alert := Alert{Check: "Vale.Terms", Match: "javascript", Span: []int{4, 13}, Line: 1}
+
+	before := &File{
+		Path:   "doc.md",
+		Lines:  []string{"Use javascript here."},
+		Alerts: []Alert{alert},
+	}
+	baseline := NewBaseline([]*File{before}, ".")
+
+	alert.Line = 3
+	after := &File{
+		Path:   "doc.md",
+		Lines:  []string{"# Intro", "", "Use javascript here.", "Use javascript again."},
+		Alerts: []Alert{alert, {Check: "Vale.Terms", Match: "javascript", Span: []int{4, 13}, Line: 4}},
+	}
+
+	stale := baseline.Suppress([]*File{after}, ".")
+	if len(stale) != 0 {
+		t.Fatalf("expected no stale entries, got %v", stale)
+	}
+
+	if len(after.Alerts) != 1 || after.Alerts[0].Line != 4 {
+		t.Fatalf("expected only the new alert to remain, got %v", after.Alerts)
+	}
+}
+
+func TestBaselineUnlintedFiles(t *testing.T) {
+	a := &File{Path: "a.md", Lines: []string{"A TODO."},
+		Alerts: []Alert{{Check: "Test.Todo", Line: 1, Match: "TODO"}}}
+	b := &File{Path: "b.md", Lines: []string{"B TODO."},
+		Alerts: []Alert{{Check: "Test.Todo", Line: 1, Match: "TODO"}}}
+
+	baseline := NewBaseline([]*File{a, b}, ".")
+
+	// Only `b.md` is linted, and its alert has since been fixed.
+	b.Alerts = []Alert{}
+
+	stale := baseline.Suppress([]*File{b}, ".")
+	if len(stale) != 1 || stale[0].Path != "b.md" {
+		t.Errorf("expected only 'b.md' to have a stale entry, got %v", stale)
+	}
+}
@@ -70,16 +70,27 @@ func NewBlueprint(path string) (*Blueprint, error) {
 	return &blueprint, nil
 }
 
+// Apply runs the blueprint's queries against the content of a data file.
 func (b *Blueprint) Apply(f *File) ([]ScopedValues, error) {
-	found := []ScopedValues{}
-
-	value, err := fileToValue(f)
+	value, err := ParseData([]byte(f.Content), f.RealExt)
 	if err != nil {
 		return nil, err
 	}
+	return b.Select(value, false)
+}
+
+// Select runs the blueprint's queries against an already-parsed value.
+//
+// If `optional` is true, queries for properties that don't exist select
+// nothing rather than returning an error.
+func (b *Blueprint) Select(value DaselValue, optional bool) ([]ScopedValues, error) {
+	found := []ScopedValues{}
 
 	for _, s := range b.Scopes {
 		selected, verr := dasel.Select(value, s.Expr)
+		if optional && errors.Is(verr, &dasel.ErrPropertyNotFound{}) {
+			selected, verr = nil, nil
+		}
 		if verr != nil {
 			return found, verr
 		}
@@ -99,19 +110,19 @@ func (b *Blueprint) Apply(f *File) ([]ScopedValues, error) {
 	return found, nil
 }
 
-func fileToValue(f *File) (DaselValue, error) {
+// ParseData parses JSON, YAML, or TOML content, as determined by `ext`.
+func ParseData(contents []byte, ext string) (DaselValue, error) {
 	var value DaselValue
 
 	// We replace block chomping indicators with a pipe to ensure that
 	// newlines are preserved.
 	//
 	// See https://yaml-multiline.info for more information.
-	text := blockChompingRegex.ReplaceAllStringFunc(f.Content, func(match string) string {
-		return blockChompingRegex.ReplaceAllString(match, `${1}|${2}`)
+	contents = blockChompingRegex.ReplaceAllFunc(contents, func(match []byte) []byte {
+		return blockChompingRegex.ReplaceAll(match, []byte(`${1}|${2}`))
 	})
 
-	contents := []byte(text)
-	switch f.RealExt {
+	switch ext {
 	case ".json":
 		err := json.Unmarshal(contents, &value)
 		if err != nil {
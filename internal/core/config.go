@@ -163,11 +163,15 @@ func DefaultStylesPath() (string, error) {
 //
 // For example, `vale --minAlertLevel=error`.
 type CLIFlags struct {
+	Addr         string
 	AlertLevel   string
 	Built        string
+	CacheDir     string
+	Diff         string
 	Glob         string
 	InExt        string
 	Output       string
+	Outputs      []string
 	Path         string
 	Sources      string
 	Filter       string
@@ -182,6 +186,11 @@ type CLIFlags struct {
 	Version      bool
 	Help         bool
 	IgnoreGlobal bool
+	Write        bool
+	DryRun       bool
+	Cache        bool
+	Profile      bool
+	Watch        bool
 }
 
 // Config holds the configuration values from both the CLI and `.vale.ini`.
@@ -191,7 +200,9 @@ type Config struct {
 	Checks            []string                   // All checks to load
 	Formats           map[string]string          // A map of unknown -> known formats
 	Asciidoctor       map[string]string          // A map of asciidoctor attributes
+	AsciidocParser    string                     // The AsciiDoc parser to use ("native" or "asciidoctor")
 	FormatToLang      map[string]string          // A map of format to lang ID
+	LintStrings       map[string]bool            // Syntax-specific string-literal linting
 	GBaseStyles       []string                   // Global base style
 	GChecks           map[string]bool            // Global checks
 	IgnoredClasses    []string                   // A list of HTML classes to ignore
@@ -209,6 +220,7 @@ type Config struct {
 	RootINI           string                     // the path to the project's .vale.ini file
 	Paths             []string                   // A list of paths to search for styles
 	ConfigFiles       []string                   // A list of configuration files to load
+	Baseline          string                     // A file of known alerts to suppress
 
 	AcceptedTokens []string `json:"-"` // Project-specific vocabulary (okay)
 	RejectedTokens []string `json:"-"` // Project-specific vocabulary (avoid)
@@ -217,6 +229,7 @@ type Config struct {
 	SecToPat     map[string]glob.Glob  `json:"-"`
 	Styles       []string              `json:"-"`
 	Blueprints   map[string]*Blueprint `json:"-"`
+	FrontMatter  map[string]*Blueprint `json:"-"` // Syntax-specific front matter blueprints
 
 	NLPEndpoint string // An external API to call for NLP-related work.
 
@@ -245,7 +258,9 @@ func NewConfig(flags *CLIFlags) (*Config, error) {
 	cfg.TokenIgnores = make(map[string][]string)
 	cfg.CommentDelimiters = make(map[string][2]string)
 	cfg.FormatToLang = make(map[string]string)
+	cfg.LintStrings = make(map[string]bool)
 	cfg.Blueprints = make(map[string]*Blueprint)
+	cfg.FrontMatter = make(map[string]*Blueprint)
 	cfg.Paths = []string{}
 	cfg.ConfigFiles = []string{}
 
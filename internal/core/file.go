@@ -42,12 +42,21 @@ type File struct {
 	ChkToCtx   map[string]string // maps a temporary context to a particular check
 	Comments   map[string]bool   // comment control statements
 	Metrics    map[string]int    // count-based metrics
+	Headings   []Heading         // the document's outline
 	history    map[string]int    // -
 	limits     map[string]int    // -
 	simple     bool              // -
 	Lookup     bool              // -
 }
 
+// A Heading is an entry in a File's outline.
+type Heading struct {
+	Level  int    // 1 (h1) through 6 (h6)
+	Text   string // the heading's text, without markup
+	Line   int    // the source line
+	Column int    // the column at which Text starts
+}
+
 // NewFile initializes a File.
 func NewFile(src string, config *Config) (*File, error) {
 	var format, ext string
@@ -68,6 +77,20 @@ func NewFile(src string, config *Config) (*File, error) {
 		lookup = true
 	}
 
+	return newFile(src, ext, format, string(fbytes), lookup, config)
+}
+
+// NewFileFromText initializes a File whose content is `text` but whose
+// format and configuration are determined by `path`.
+//
+// This is used by editor integrations, where the content of an unsaved buffer
+// may not match the file on disk.
+func NewFileFromText(path, text string, config *Config) (*File, error) {
+	ext, format := FormatFromExt(path, config.Formats)
+	return newFile(path, ext, format, text, false, config)
+}
+
+func newFile(src, ext, format, text string, lookup bool, config *Config) (*File, error) {
 	filepaths := []string{src}
 	normed := system.ReplaceFileExt(src, config.Formats)
 
@@ -111,7 +134,7 @@ func NewFile(src string, config *Config) (*File, error) {
 			break
 		}
 	}
-	content := Sanitize(string(fbytes))
+	content := Sanitize(text)
 
 	// NOTE: We need to perform a clone here because we perform inplace editing
 	// of the files contents that we don't want reflected in `lines`.
@@ -268,33 +291,50 @@ func (f *File) AddAlert(a Alert, blk nlp.Block, lines, pad int, lookup bool) {
 		a.Offset = append(a.Offset, strings.Fields(ctx[0:a.Span[0]])...)
 	}
 
-	if !lookup {
+	// Some rules (e.g., `headings`) locate their own alerts since they work
+	// with the file's outline rather than its text.
+	located := a.Line > 0
+	if !lookup && !located {
 		a.Line, a.Span = f.assignLoc(ctx, blk, pad, a)
 	}
-	if (!lookup && a.Span[0] < 0) || lookup {
+	if !located && ((!lookup && a.Span[0] < 0) || lookup) {
 		a.Line, a.Span = f.FindLoc(ctx, blk.Text, pad, lines, a)
 	}
 
 	if a.Span[0] > 0 {
 		f.ChkToCtx[a.Check], _ = Substitute(ctx, a.Match, '#')
-		if !a.Hide {
-			// Ensure that we're not double-reporting an Alert:
-			entry := strings.Join([]string{
-				strconv.Itoa(a.Line),
-				strconv.Itoa(a.Span[0]),
-				a.Check}, "-")
-
-			if _, found := f.history[entry]; !found {
-				// Check rule-assigned limits for reporting:
-				count, occur := f.limits[a.Check]
-				if (!occur || a.Limit == 0) || count < a.Limit {
-					f.Alerts = append(f.Alerts, a)
-
-					f.history[entry] = 1
-					if a.Limit > 0 {
-						f.limits[a.Check]++
-					}
-				}
+		f.record(a)
+	}
+}
+
+// AddLocatedAlert adds an Alert whose line and span have already been
+// calculated by the caller.
+func (f *File) AddLocatedAlert(a Alert) {
+	if a.Span[0] > 0 {
+		f.record(a)
+	}
+}
+
+func (f *File) record(a Alert) {
+	if a.Hide {
+		return
+	}
+
+	// Ensure that we're not double-reporting an Alert:
+	entry := strings.Join([]string{
+		strconv.Itoa(a.Line),
+		strconv.Itoa(a.Span[0]),
+		a.Check}, "-")
+
+	if _, found := f.history[entry]; !found {
+		// Check rule-assigned limits for reporting:
+		count, occur := f.limits[a.Check]
+		if (!occur || a.Limit == 0) || count < a.Limit {
+			f.Alerts = append(f.Alerts, a)
+
+			f.history[entry] = 1
+			if a.Limit > 0 {
+				f.limits[a.Check]++
 			}
 		}
 	}
@@ -33,21 +33,16 @@ var CommentsByNormedExt = map[string]map[string]string{
 		"blockStart": `$^`,
 		"blockEnd":   `$^`,
 	},
+	".pl": {
+		"inline":     `(#.+)`,
+		"blockStart": `^(=[a-zA-Z].*)`,
+		"blockEnd":   `^(=cut.*)`,
+	},
 	".ps1": {
 		"inline":     `(#.+)`,
 		"blockStart": `(<#.*)`,
 		"blockEnd":   `(.*#>)`,
 	},
-	".php": {
-		"inline":     `(//.+)|(/\*.+\*/)|(#.+)`,
-		"blockStart": `(/\*.*)`,
-		"blockEnd":   `(.*\*/)`,
-	},
-	".lua": {
-		"inline":     `(-- .+)`,
-		"blockStart": `(-{2,3}\[\[.*)`,
-		"blockEnd":   `(.*\]\])`,
-	},
 	".hs": {
 		"inline":     `(-- .+)`,
 		"blockStart": `(\{-.*)`,
@@ -63,35 +58,40 @@ var FormatByExtension = map[string][]string{
 	`\.(?:clj|cljs|cljc|cljd)$`:                {".clj", "code"},
 	`\.(?:cpp|cc|c|cp|cxx|c\+\+|h|hpp|h\+\+)$`: {".cpp", "code"},
 	`\.(?:css)$`:                      {".css", "code"},
-	`\.(?:cs|csx)$`:                   {".c", "code"},
+	`\.(?:cs|csx)$`:                   {".cs", "code"},
 	`\.(?:dita)$`:                     {".dita", "markup"},
 	`\.(?:go)$`:                       {".go", "code"},
 	`\.(?:hs)$`:                       {".hs", "code"},
 	`\.(?:html|htm|shtml|xhtml)$`:     {".html", "markup"},
-	`\.(?:java|bsh)$`:                 {".c", "code"},
+	`\.(?:ipynb)$`:                    {".ipynb", "markup"},
+	`\.(?:java|bsh)$`:                 {".java", "code"},
 	`\.(?:jl)$`:                       {".jl", "code"},
+	`\.(?:kt|kts)$`:                   {".kt", "code"},
 	`\.(?:js|jsx)$`:                   {".js", "code"},
 	`\.(?:lua)$`:                      {".lua", "code"},
 	`\.(?:md|mdown|markdown|markdn)$`: {".md", "markup"},
 	`\.(?:mdx)$`:                      {".mdx", "markup"},
 	`\.(?:org)$`:                      {".org", "markup"},
 	`\.(?:php)$`:                      {".php", "code"},
-	`\.(?:pl|pm|pod)$`:                {".r", "code"},
+	`\.(?:pl|pm|pod)$`:                {".pl", "code"},
 	`\.(?:proto)$`:                    {".proto", "code"},
 	`\.(?:ps1|psm1|psd1)$`:            {".ps1", "code"},
 	`\.(?:rb|Gemfile|Rakefile|Brewfile|gemspec)$`: {".rb", "code"},
-	`\.(?:rs)$`:        {".rs", "code"},
-	`\.(?:rst|rest)$`:  {".rst", "markup"},
-	`\.(?:r|R)$`:       {".r", "code"},
-	`\.(?:sass|less)$`: {".c", "code"},
-	`\.(?:scala|sbt)$`: {".c", "code"},
-	`\.(?:swift)$`:     {".c", "code"},
-	`\.(?:ts|tsx)$`:    {".ts", "code"},
-	`\.(?:txt)$`:       {".txt", "text"},
-	`\.(?:xml)$`:       {".xml", "markup"},
-	`\.(?:yaml|yml)$`:  {".yml", "data"},
-	`\.(?:json)$`:      {".json", "data"},
-	`\.(?:toml)$`:      {".toml", "data"},
+	`\.(?:rs)$`:          {".rs", "code"},
+	`\.(?:rst|rest)$`:    {".rst", "markup"},
+	`\.(?:r|R)$`:         {".r", "code"},
+	`\.(?:sass|less)$`:   {".c", "code"},
+	`\.(?:scala|sbt)$`:   {".scala", "code"},
+	`\.(?:sh|bash|zsh)$`: {".sh", "code"},
+	`\.(?:sql)$`:         {".sql", "code"},
+	`\.(?:swift)$`:       {".swift", "code"},
+	`\.(?:ts)$`:          {".ts", "code"},
+	`\.(?:tsx)$`:         {".tsx", "code"},
+	`\.(?:txt)$`:         {".txt", "text"},
+	`\.(?:xml)$`:         {".xml", "markup"},
+	`\.(?:yaml|yml)$`:    {".yml", "data"},
+	`\.(?:json)$`:        {".json", "data"},
+	`\.(?:toml)$`:        {".toml", "data"},
 }
 
 // FormatFromExt takes a file extension and returns its [normExt, format]
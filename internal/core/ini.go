@@ -122,6 +122,10 @@ var syntaxOpts = map[string]func(string, *ini.Section, *Config) error{
 		cfg.FormatToLang[label] = sec.Key("Lang").String()
 		return nil
 	},
+	"LintStrings": func(label string, sec *ini.Section, cfg *Config) error { //nolint:unparam
+		cfg.LintStrings[label] = sec.Key("LintStrings").MustBool(false)
+		return nil
+	},
 	"Blueprint": func(label string, sec *ini.Section, cfg *Config) error {
 		name := sec.Key("Blueprint").String()
 
@@ -138,6 +142,27 @@ var syntaxOpts = map[string]func(string, *ini.Section, *Config) error{
 		cfg.Blueprints[label] = blueprint
 		return nil
 	},
+	"FrontMatter": func(label string, sec *ini.Section, cfg *Config) error {
+		name := sec.Key("FrontMatter").String()
+
+		path := FindConfigAsset(cfg, name+".yml", BlueprintsDir)
+		if path == "" {
+			return fmt.Errorf("blueprint '%s' not found", name)
+		}
+
+		blueprint, err := NewBlueprint(path)
+		if err != nil {
+			return err
+		} else if blueprint.Engine != "dasel" {
+			return NewE201FromTarget(
+				fmt.Sprintf("front matter blueprints must use the 'dasel' engine, not '%s'", blueprint.Engine),
+				name,
+				cfg.Flags.Path)
+		}
+
+		cfg.FrontMatter[label] = blueprint
+		return nil
+	},
 }
 
 var globalOpts = map[string]func(*ini.Section, *Config){
@@ -243,6 +268,28 @@ var coreOpts = map[string]func(*ini.Section, *Config) error{
 		}
 		return nil
 	},
+	"Baseline": func(sec *ini.Section, cfg *Config) error { //nolint:unparam
+		values := sec.Key("Baseline").ValueWithShadows()
+		if len(values) > 0 {
+			candidate := filepath.FromSlash(values[len(values)-1])
+			cfg.Baseline = system.DeterminePath(cfg.ConfigFile(), candidate)
+		}
+		return nil
+	},
+	"AsciidocParser": func(sec *ini.Section, cfg *Config) error {
+		values := sec.Key("AsciidocParser").StringsWithShadows(",")
+		if len(values) > 0 {
+			parser := values[len(values)-1]
+			if !StringInSlice(parser, []string{"native", "asciidoctor"}) {
+				return NewE201FromTarget(
+					"AsciidocParser must be 'native' or 'asciidoctor'.",
+					parser,
+					cfg.Flags.Path)
+			}
+			cfg.AsciidocParser = parser
+		}
+		return nil
+	},
 	"NLPEndpoint": func(sec *ini.Section, cfg *Config) error { //nolint:unparam
 		cfg.NLPEndpoint = sec.Key("NLPEndpoint").MustString("")
 
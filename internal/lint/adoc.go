@@ -3,10 +3,13 @@ package lint
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint/adoc"
+	"github.com/errata-ai/vale/v3/internal/lint/markup"
 	"github.com/errata-ai/vale/v3/internal/nlp"
 	"github.com/errata-ai/vale/v3/internal/system"
 )
@@ -35,6 +38,18 @@ var adocArgs = []string{
 }
 
 func (l *Linter) lintADoc(f *core.File) error {
+	if l.Manager.Config.AsciidocParser == "asciidoctor" {
+		return l.lintADocExternal(f)
+	}
+
+	return l.lintMarkup(f, func(src string) []markup.Block {
+		return adoc.Parse(src, l.Manager.Config.Asciidoctor, filepath.Dir(f.Path))
+	})
+}
+
+// lintADocExternal converts the file using the `asciidoctor` executable,
+// which users can opt into with `AsciidocParser = asciidoctor`.
+func (l *Linter) lintADocExternal(f *core.File) error {
 	var html string
 	var err error
 
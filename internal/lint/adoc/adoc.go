@@ -0,0 +1,579 @@
+// Package adoc parses AsciiDoc into the blocks of text that we lint (see
+// `internal/lint/markup`).
+//
+// The blocks mirror the structure produced by Asciidoctor (sections,
+// paragraphs, lists, tables, admonitions, etc.). Text that shouldn't be
+// linted -- listing blocks, comments, block attributes, and so on -- is
+// emitted as `pre`.
+package adoc
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/lint/markup"
+)
+
+var (
+	reAttrEntry   = regexp.MustCompile(`^:(!?)([\w][\w-]*)(!?):(?:\s+(.*))?$`)
+	reConditional = regexp.MustCompile(`^(ifdef|ifndef|ifeval|endif)::([^\[]*)\[(.*)\]$`)
+	reSection     = regexp.MustCompile(`^(={1,6}|#{1,6})\s+(\S.*)$`)
+	reBlockTitle  = regexp.MustCompile(`^\.[^\s.]`)
+	reBlockAttrs  = regexp.MustCompile(`^\[.*\]$`)
+	reBlockMacro  = regexp.MustCompile(`^([\w-]+)::(\S*?)\[(.*)\]$`)
+	reAdmonition  = regexp.MustCompile(`^(NOTE|TIP|IMPORTANT|WARNING|CAUTION):\s+(.*)$`)
+	reUnordered   = regexp.MustCompile(`^\s*(\*{1,5}|-)\s+(.*)$`)
+	reOrdered     = regexp.MustCompile(`^\s*(\.{1,5}|\d+\.|[a-zA-Z]\.|[ivxIVX]+\))\s+(.*)$`)
+	reDescription = regexp.MustCompile(`^(\S.*?)(:{2,4}|;;)(?:\s+(.*))?$`)
+	reChecklist   = regexp.MustCompile(`^\[[ xX*]\]\s+`)
+	reBreak       = regexp.MustCompile(`^(?:'{3,}|-{3}|\*{3}|<{3})$`)
+)
+
+// admonitions are the labels of AsciiDoc's admonition blocks, whose content is
+// placed inside an `<aside>`.
+var admonitions = map[string]bool{
+	"NOTE": true, "TIP": true, "IMPORTANT": true, "WARNING": true, "CAUTION": true,
+}
+
+// maxIncludeDepth limits how deeply we follow `include::` directives when
+// looking for attribute entries.
+const maxIncludeDepth = 8
+
+type converter struct {
+	markup.Builder
+
+	// attrs holds the document attributes, including those defined in the
+	// `[asciidoctor]` section of the user's configuration.
+	attrs map[string]string
+	// dir is used to resolve `include::` directives.
+	dir string
+
+	// style and title hold the block attributes (e.g., `[source]`) and block
+	// title (e.g., `.Title`) that apply to the next block.
+	style string
+	opts  map[string]string
+	title markup.Line
+
+	header bool
+}
+
+// Parse returns the blocks of the given AsciiDoc, in document order.
+//
+// `attrs` are document attributes, where values of "YES" and "NO" set and
+// unset an attribute, respectively. `dir` is the directory that includes are
+// resolved against.
+func Parse(src string, attrs map[string]string, dir string) []markup.Block {
+	c := converter{attrs: map[string]string{}, dir: dir, header: true}
+	for k, v := range attrs {
+		switch v {
+		case "YES":
+			c.attrs[k] = ""
+		case "NO":
+		default:
+			c.attrs[k] = v
+		}
+	}
+
+	c.blocks(markup.Split(src))
+	return c.Blocks
+}
+
+func (c *converter) blocks(lines []markup.Line) {
+	for i := 0; i < len(lines); {
+		if markup.IsBlank(lines[i].Text) {
+			i++
+			continue
+		}
+		i = c.block(lines, i)
+	}
+}
+
+// text emits the given lines, which contain inline markup, as a block.
+func (c *converter) text(tag string, lines []markup.Line) {
+	s, pos := markup.Join(lines)
+
+	t := &markup.Text{}
+	c.inline(t, s, pos)
+	c.Emit(tag, t)
+}
+
+// block parses the block starting at `lines[i]`, returning the index of the
+// next line.
+func (c *converter) block(lines []markup.Line, i int) int {
+	l := lines[i].TrimRight()
+	line := l.Text
+
+	header := c.header
+	c.header = false
+
+	if strings.HasPrefix(line, "//") {
+		if delim := delimiter(line); delim != "" {
+			end := closing(lines, i, delim)
+			c.Pre(lines[i:end])
+			return end
+		}
+		c.Pre(lines[i : i+1])
+		return i + 1
+	}
+
+	if m := reAttrEntry.FindStringSubmatch(line); m != nil {
+		end := i + 1
+		value := m[4]
+		for strings.HasSuffix(value, ` \`) && end < len(lines) {
+			value = strings.TrimSuffix(value, `\`) + strings.TrimSpace(lines[end].Text)
+			end++
+		}
+		c.setAttr(m[2], value, m[1] == "!" || m[3] == "!")
+		c.Pre(lines[i:end])
+		return end
+	}
+
+	if m := reConditional.FindStringSubmatchIndex(line); m != nil {
+		return c.conditional(lines, i, l, m)
+	}
+
+	if reBlockAttrs.MatchString(line) {
+		c.blockAttributes(line)
+		c.Pre(lines[i : i+1])
+		return i + 1
+	}
+
+	if reBlockTitle.MatchString(line) {
+		c.title = l.From(1)
+		return i + 1
+	}
+
+	if m := reSection.FindStringSubmatchIndex(line); m != nil {
+		c.resetAttributes()
+
+		level := m[3] - m[2]
+		marker := line[m[2]:m[3]]
+
+		title := l.From(m[4])
+		title.Text = strings.TrimRight(strings.TrimSuffix(title.Text, " "+marker), " ")
+		c.text("h"+string(rune('0'+level)), []markup.Line{title})
+
+		if header && level == 1 {
+			return c.documentHeader(lines, i+1)
+		}
+		return i + 1
+	}
+
+	if delim := delimiter(line); delim != "" {
+		return c.delimited(lines, i, delim)
+	}
+
+	if m := reBlockMacro.FindStringSubmatchIndex(line); m != nil {
+		attrs := l.From(m[6])
+		attrs.Text = line[m[6]:m[7]]
+
+		c.blockMacro(line[m[2]:m[3]], line[m[4]:m[5]], attrs)
+		c.Pre(lines[i : i+1])
+		c.resetAttributes()
+		return i + 1
+	}
+
+	if reBreak.MatchString(line) {
+		// A thematic or page break, which has no text.
+		return i + 1
+	}
+
+	switch {
+	case reUnordered.MatchString(line) && !strings.HasPrefix(line, " "):
+		return c.list(lines, i, reUnordered, "ul")
+	case reOrdered.MatchString(line) && !strings.HasPrefix(line, " "):
+		return c.list(lines, i, reOrdered, "ol")
+	case isDescription(line):
+		return c.descriptionList(lines, i)
+	case markup.IndentOf(line) > 0:
+		// A literal paragraph.
+		end := i
+		for end < len(lines) && !markup.IsBlank(lines[end].Text) {
+			end++
+		}
+		c.Pre(lines[i:end])
+		c.resetAttributes()
+		return end
+	}
+
+	return c.paragraph(lines, i)
+}
+
+func (c *converter) paragraph(lines []markup.Line, i int) int {
+	para := []markup.Line{}
+	comments := []markup.Line{}
+
+	for ; i < len(lines) && !markup.IsBlank(lines[i].Text); i++ {
+		l := lines[i].TrimRight()
+		line := l.Text
+		if len(para) > 0 && (delimiter(line) != "" || reBlockAttrs.MatchString(line) ||
+			reConditional.MatchString(line) || strings.HasPrefix(line, "include::")) {
+			break
+		} else if strings.HasPrefix(line, "//") && !strings.HasPrefix(line, "///") {
+			comments = append(comments, l)
+			continue
+		}
+		para = append(para, l)
+	}
+
+	if len(comments) > 0 {
+		c.Pre(comments)
+	}
+
+	style := c.style
+	title := c.title
+	c.resetAttributes()
+
+	label := ""
+	if m := reAdmonition.FindStringSubmatchIndex(para[0].Text); m != nil && style == "" {
+		label = para[0].Text[m[2]:m[3]]
+		para[0] = para[0].From(m[4])
+	} else if admonitions[style] {
+		label = style
+	}
+
+	c.blockTitle(title)
+
+	switch style {
+	case "source", "listing", "literal", "comment", "stem", "latexmath", "asciimath":
+		c.Pre(para)
+		return i
+	case "pass":
+		c.Raw(para)
+		return i
+	}
+
+	switch {
+	case label != "":
+		c.Open("aside")
+		c.text("p", trimLines(para))
+		c.Close()
+	case style == "quote" || style == "verse":
+		c.Open("blockquote")
+		c.text("p", trimLines(para))
+		c.Close()
+	default:
+		c.text("p", trimLines(para))
+	}
+
+	return i
+}
+
+// documentHeader skips the author and revision lines that may follow the
+// document title, processing any attribute entries.
+func (c *converter) documentHeader(lines []markup.Line, i int) int {
+	start := i
+	for ; i < len(lines) && !markup.IsBlank(lines[i].Text); i++ {
+		line := lines[i].TrimRight().Text
+		if m := reAttrEntry.FindStringSubmatch(line); m != nil {
+			c.setAttr(m[2], m[4], m[1] == "!" || m[3] == "!")
+		}
+	}
+
+	if i > start {
+		c.Pre(lines[start:i])
+	}
+	return i
+}
+
+// delimited parses a delimited block (e.g., `----` or `====`).
+func (c *converter) delimited(lines []markup.Line, i int, delim string) int {
+	end := closing(lines, i, delim)
+
+	// An unterminated block extends to the end of the document.
+	inner := lines[i+1 : end]
+	if end > i+1 && lines[end-1].TrimRight().Text == delim {
+		inner = lines[i+1 : end-1]
+	}
+
+	style := c.style
+	title := c.title
+	opts := c.opts
+	c.resetAttributes()
+
+	table := strings.HasSuffix(delim, "===") && strings.ContainsRune("|,:!", rune(delim[0]))
+	if !table {
+		c.blockTitle(title)
+	}
+
+	switch {
+	case table:
+		c.table(inner, delim[0], title, opts)
+	case delim[0] == '-' && delim != "--", delim[0] == '.', delim[0] == '`', delim[0] == '/':
+		c.Pre(lines[i:end])
+	case delim[0] == '+':
+		if style == "stem" || style == "latexmath" || style == "asciimath" {
+			c.Pre(lines[i:end])
+		} else {
+			// Passthrough content is included as-is.
+			c.Raw(inner)
+		}
+	case style == "source" || style == "listing" || style == "literal" || style == "comment":
+		c.Pre(lines[i:end])
+	case admonitions[style]:
+		c.Open("aside")
+		c.blocks(inner)
+		c.Close()
+	case delim[0] == '_':
+		c.Open("blockquote")
+		c.blocks(inner)
+		c.Close()
+	default:
+		// Example, sidebar, and open blocks.
+		c.Open("div")
+		c.blocks(inner)
+		c.Close()
+	}
+
+	return end
+}
+
+func (c *converter) blockMacro(name, target string, attrs markup.Line) {
+	switch name {
+	case "image":
+		alt := imageAlt(attrs.Text)
+		if idx := strings.Index(attrs.Text, alt); alt != "" && idx >= 0 {
+			at := attrs.From(idx)
+			at.Text = alt
+			c.Alt(markup.Join([]markup.Line{at}))
+		}
+		if c.title.Text != "" {
+			c.text("figcaption", []markup.Line{c.title})
+		}
+	case "include":
+		c.include(c.substitute(target), 0)
+	}
+}
+
+// include processes the attribute entries of an included file.
+//
+// The included content itself isn't linted here since it doesn't exist in
+// the current file -- it's linted on its own.
+func (c *converter) include(target string, depth int) {
+	if depth > maxIncludeDepth || strings.Contains(target, "://") {
+		return
+	}
+
+	path := target
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(c.dir, path)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if m := reAttrEntry.FindStringSubmatch(line); m != nil {
+			c.setAttr(m[2], m[4], m[1] == "!" || m[3] == "!")
+		} else if m := reBlockMacro.FindStringSubmatch(line); m != nil && m[1] == "include" {
+			nested := converter{attrs: c.attrs, dir: filepath.Dir(path)}
+			nested.include(c.substitute(m[2]), depth+1)
+		}
+	}
+}
+
+// conditional handles the `ifdef`, `ifndef`, `ifeval`, and `endif`
+// preprocessor directives, where m holds the submatch indices of
+// reConditional in l.
+func (c *converter) conditional(lines []markup.Line, i int, l markup.Line, m []int) int {
+	kind, names := l.Text[m[2]:m[3]], l.Text[m[4]:m[5]]
+
+	content := l.From(m[6])
+	content.Text = l.Text[m[6]:m[7]]
+
+	c.Pre(lines[i : i+1])
+	if kind == "endif" || kind == "ifeval" {
+		// We don't evaluate expressions, so `ifeval` content is always
+		// included.
+		return i + 1
+	}
+
+	ok := c.defined(names)
+	if kind == "ifndef" {
+		ok = !ok
+	}
+
+	if content.Text != "" {
+		// The single-line form: `ifdef::attr[content]`.
+		if ok {
+			c.blocks([]markup.Line{content})
+		}
+		return i + 1
+	} else if ok {
+		return i + 1
+	}
+
+	// Skip to the matching `endif`.
+	depth := 0
+	end := i + 1
+	for ; end < len(lines); end++ {
+		line := lines[end].TrimRight().Text
+		if cm := reConditional.FindStringSubmatch(line); cm != nil {
+			if cm[1] == "endif" {
+				if depth == 0 {
+					break
+				}
+				depth--
+			} else if cm[3] == "" {
+				depth++
+			}
+		}
+	}
+
+	c.Pre(lines[i+1 : end])
+	return end
+}
+
+func (c *converter) defined(names string) bool {
+	if strings.Contains(names, "+") {
+		for _, name := range strings.Split(names, "+") {
+			if _, ok := c.attrs[strings.TrimSpace(name)]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, name := range strings.Split(names, ",") {
+		if _, ok := c.attrs[strings.TrimSpace(name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *converter) setAttr(name, value string, unset bool) {
+	if unset {
+		delete(c.attrs, name)
+	} else {
+		c.attrs[name] = c.substitute(strings.TrimSpace(value))
+	}
+}
+
+// blockAttributes parses a block attribute line, such as `[source,python]`
+// or `[NOTE]`.
+func (c *converter) blockAttributes(line string) {
+	inner := strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+	if strings.HasPrefix(inner, "[") {
+		// An anchor: `[[id]]`.
+		return
+	}
+
+	c.opts = map[string]string{}
+	for idx, attr := range splitAttrs(inner) {
+		if key, value, found := strings.Cut(attr, "="); found {
+			c.opts[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+		} else if idx == 0 {
+			// The first positional attribute is the block style, which may
+			// be followed by shorthand IDs, roles, and options.
+			style := strings.TrimSpace(attr)
+			if cut := strings.IndexAny(style, "#.%"); cut >= 0 {
+				for _, opt := range strings.Split(style[cut:], "%")[1:] {
+					c.opts[opt+"-option"] = ""
+				}
+				style = style[:cut]
+			}
+			if style != "" {
+				c.style = style
+			}
+		}
+	}
+}
+
+func (c *converter) resetAttributes() {
+	c.style = ""
+	c.title = markup.Line{}
+	c.opts = nil
+}
+
+func (c *converter) blockTitle(title markup.Line) {
+	if title.Text != "" {
+		c.text("div", []markup.Line{title})
+	}
+}
+
+// delimiter returns the delimiter of the block that `line` opens, if any.
+func delimiter(line string) string {
+	switch {
+	case line == "--", strings.HasPrefix(line, "```"):
+		if strings.HasPrefix(line, "```") {
+			return "```"
+		}
+		return line
+	case len(line) == 4 && line[0] != '|' && strings.HasSuffix(line, "===") &&
+		strings.ContainsRune("|,:!", rune(line[0])):
+		return line
+	case len(line) >= 4 && strings.Trim(line, line[:1]) == "" &&
+		strings.ContainsRune("-./=*_+", rune(line[0])):
+		return line
+	case strings.HasPrefix(line, "|===") && strings.Trim(line[1:], "=") == "":
+		return line
+	}
+	return ""
+}
+
+// closing returns the index just past the line that closes the block opened
+// at `lines[i]`.
+func closing(lines []markup.Line, i int, delim string) int {
+	for j := i + 1; j < len(lines); j++ {
+		line := lines[j].TrimRight().Text
+		if line == delim || (delim == "```" && line == "```") {
+			return j + 1
+		}
+	}
+	return len(lines)
+}
+
+// splitAttrs splits an attribute list on commas, respecting quotes.
+func splitAttrs(s string) []string {
+	attrs := []string{}
+
+	quoted := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			attrs = append(attrs, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(attrs, s[start:])
+}
+
+func imageAlt(attrs string) string {
+	for idx, attr := range splitAttrs(attrs) {
+		attr = strings.TrimSpace(attr)
+		if key, value, found := strings.Cut(attr, "="); found {
+			if strings.TrimSpace(key) == "alt" {
+				return strings.Trim(strings.TrimSpace(value), `"`)
+			}
+		} else if idx == 0 {
+			return strings.Trim(attr, `"`)
+		}
+	}
+	return ""
+}
+
+// trimLines trims the lines of a paragraph, including any trailing ` +`
+// (i.e., a hard line break).
+func trimLines(lines []markup.Line) []markup.Line {
+	out := make([]markup.Line, len(lines))
+	for i, l := range lines {
+		out[i] = l.Trim()
+		out[i].Text = strings.TrimSuffix(out[i].Text, " +")
+	}
+	return out
+}
+
+func isDescription(line string) bool {
+	m := reDescription.FindStringSubmatch(line)
+	return m != nil && !strings.Contains(m[1], "://") && !strings.HasSuffix(m[1], ":")
+}
@@ -0,0 +1,157 @@
+package adoc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/lint/markup"
+)
+
+// describe returns a summary of each block: its tags, followed by its text.
+func describe(blocks []markup.Block) []string {
+	out := []string{}
+	for _, b := range blocks {
+		out = append(out, strings.Join(b.Tags, ">")+": "+string(b.Text))
+	}
+	return out
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name     string
+		src      string
+		attrs    map[string]string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "sections",
+			src:      "= Title\nJane Doe\n\n== Section\n\nText.\n",
+			contains: []string{"h1: Title", "pre: Jane Doe", "h2: Section", "p: Text."},
+		},
+		{
+			name:     "listing",
+			src:      "[source,go]\n----\n// XXX\n----\n\nDone.\n",
+			contains: []string{"pre: [source,go]", "pre: ----\n// XXX\n----", "p: Done."},
+		},
+		{
+			name:     "admonitions",
+			src:      "NOTE: First.\n\n[TIP]\n====\nSecond.\n====\n",
+			contains: []string{"aside>p: First.", "aside>p: Second."},
+			excludes: []string{"p: NOTE: First."},
+		},
+		{
+			name:     "attributes",
+			src:      ":product: Acme\n\nUse {product} and {missing}{nbsp}now.\n",
+			attrs:    map[string]string{"experimental": "YES"},
+			contains: []string{"p: Use Acme and  now."},
+		},
+		{
+			name:     "conditionals",
+			src:      "ifdef::draft[]\nHidden.\nendif::[]\nifndef::draft[]\nShown.\nendif::[]\n",
+			attrs:    map[string]string{"draft": "NO"},
+			contains: []string{"pre: Hidden.", "p: Shown."},
+		},
+		{
+			name:     "table",
+			src:      ".Caption\n|===\n|A |B\n\n|1\n|2\n|===\n",
+			contains: []string{"table>caption: Caption", "table>tr>th: A", "table>tr>th: B", "table>tr>td: 1", "table>tr>td: 2"},
+		},
+		{
+			name:     "lists",
+			src:      "* One\n** Nested\n* Two\n\nTerm:: Definition.\n",
+			contains: []string{"ul>li>p: One", "ul>li>ul>li>p: Nested", "ul>li>p: Two", "dl>dt: Term", "dl>dd>p: Definition."},
+		},
+		{
+			name:     "image",
+			src:      "image::a.png[A picture]\n",
+			contains: []string{"img: A picture"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			blocks := Parse(tc.src, tc.attrs, ".")
+			got := describe(blocks)
+
+			for _, s := range tc.contains {
+				found := false
+				for _, b := range got {
+					found = found || b == s
+				}
+				if !found {
+					t.Errorf("expected %q in %q", s, got)
+				}
+			}
+			for _, s := range tc.excludes {
+				for _, b := range got {
+					if b == s {
+						t.Errorf("unexpected %q in %q", s, got)
+					}
+				}
+			}
+
+			if strings.Contains(tc.src, "{") {
+				// The values of attribute references aren't in the source.
+				return
+			}
+			for _, b := range blocks {
+				for i, c := range b.Text {
+					if c != '\n' && tc.src[b.Pos[i]] != c {
+						t.Errorf("%q: byte %d (%q) maps to %q", b.Text, i, c, tc.src[b.Pos[i]])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestInline(t *testing.T) {
+	src := "A *bold*, _em_, `code`, https://example.com[link], <https://example.com>, and pass:[<!-- vale off -->]."
+
+	pos := make([]int, len(src))
+	for i := range pos {
+		pos[i] = i
+	}
+
+	c := converter{attrs: map[string]string{}}
+	txt := &markup.Text{}
+	c.inline(txt, src, pos)
+
+	expected := "A bold, em, code, link, https://example.com, and <!-- vale off -->."
+	if string(txt.Buf) != expected {
+		t.Fatalf("expected %q, got %q", expected, txt.Buf)
+	}
+
+	tags := []string{}
+	for _, s := range txt.Spans {
+		tags = append(tags, s.Tag+":"+string(txt.Buf[s.Start:s.End]))
+	}
+
+	spans := "strong:bold em:em code:code a:link a:https://example.com :<!-- vale off -->"
+	if strings.Join(tags, " ") != spans {
+		t.Errorf("expected %q, got %q", spans, strings.Join(tags, " "))
+	}
+
+	for i, c := range txt.Buf {
+		if src[txt.Pos[i]] != c {
+			t.Errorf("byte %d (%q) maps to %q", i, c, src[txt.Pos[i]])
+		}
+	}
+}
+
+func TestIncludeAttributes(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "attrs.adoc"), []byte(":product: Acme\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := describe(Parse("include::attrs.adoc[]\n\nUse {product}.\n", nil, dir))
+	if !strings.Contains(strings.Join(got, "\n"), "p: Use Acme.") {
+		t.Errorf("expected the included attribute to be resolved: %q", got)
+	}
+}
@@ -0,0 +1,412 @@
+package adoc
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v3/internal/lint/markup"
+)
+
+var (
+	reAttrRef     = regexp.MustCompile(`^\{([\w][\w-]*)\}`)
+	reCounter     = regexp.MustCompile(`^\{counter2?:[\w-]+(?::[^}]*)?\}`)
+	reURL         = regexp.MustCompile(`^(?:https?|ftp|irc|file)://[^\s\[\]<>]*[^\s\[\]<>.,;:!?)'"]`)
+	reLinkMacro   = regexp.MustCompile(`^(?:link|mailto):([^\s\[]+)\[`)
+	reInlineMacro = regexp.MustCompile(`^([a-z][\w-]*):([^\s\[]*)\[`)
+	reXref        = regexp.MustCompile(`^<<([^,>]+)(?:,\s*([^>]*))?>>`)
+	reAnchor      = regexp.MustCompile(`^\[\[\[?[^\]]+\]\]\]?`)
+	reRoleSpan    = regexp.MustCompile("^\\[[^\\]]*\\]([#*_`])")
+)
+
+// builtins are Asciidoctor's character replacement attributes.
+var builtins = map[string]string{
+	"amp": "&", "apos": "'", "asterisk": "*", "backslash": `\`,
+	"backtick": "`", "blank": "", "brvbar": "¦", "caret": "^", "cpp": "C++",
+	"deg": "°", "empty": "", "endsb": "]", "gt": ">", "ldquo": "“",
+	"lsquo": "‘", "lt": "<", "nbsp": " ", "plus": "+", "pp": "++",
+	"quot": `"`, "rdquo": "”", "rsquo": "’", "sp": " ", "startsb": "[",
+	"tilde": "~", "two-colons": "::", "two-semicolons": ";;", "vbar": "|",
+	"wj": "", "zwsp": "",
+}
+
+// quotes are the constrained and unconstrained formatting marks.
+var quotes = []struct {
+	mark string
+	tag  string
+}{
+	{"**", "strong"}, {"__", "em"}, {"``", "code"}, {"##", "span"},
+	{"*", "strong"}, {"_", "em"}, {"`", "code"}, {"#", "span"},
+	{"^", "sup"}, {"~", "sub"},
+}
+
+// inline appends s, whose bytes were found at the given source offsets, to t,
+// recording its inline elements (e.g., `code`) as spans.
+func (c *converter) inline(t *markup.Text, s string, pos []int) {
+	i := 0
+	for i < len(s) {
+		rest := s[i:]
+		start := isStartBoundary(s, i)
+
+		if rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("*_`#^~+{[<\\", rune(rest[1])) {
+			t.Add(rest[1:2], pos[i+1:])
+			i += 2
+			continue
+		}
+
+		if strings.HasPrefix(rest, "pass:") {
+			if open := strings.IndexByte(rest, '['); open > 0 && !strings.ContainsAny(rest[5:open], " \t") {
+				if end := matchBracket(rest, open); end > 0 {
+					// Passthrough content (e.g., an HTML comment) is included
+					// as-is.
+					raw(t, rest[open+1:end], pos[i+open+1:])
+					i += end + 1
+					continue
+				}
+			}
+		}
+
+		if strings.HasPrefix(rest, "+++") {
+			if end := strings.Index(rest[3:], "+++"); end >= 0 {
+				raw(t, rest[3:3+end], pos[i+3:])
+				i += end + 6
+				continue
+			}
+		} else if strings.HasPrefix(rest, "++") {
+			if end := strings.Index(rest[2:], "++"); end > 0 {
+				t.Add(rest[2:2+end], pos[i+2:])
+				i += end + 4
+				continue
+			}
+		} else if rest[0] == '+' && start {
+			if end := constrainedEnd(rest, "+"); end > 0 {
+				t.Add(rest[1:end], pos[i+1:])
+				i += end + 1
+				continue
+			}
+		}
+
+		if strings.HasPrefix(rest, "\"`") || strings.HasPrefix(rest, "'`") {
+			// Curved quotes: "`text`" and '`text`'.
+			closer := "`" + rest[:1]
+			if end := strings.Index(rest[2:], closer); end > 0 {
+				t.Add(rest[:1], pos[i:])
+				c.inline(t, rest[2:2+end], pos[i+2:i+2+end])
+				t.Add(rest[:1], pos[i+3+end:])
+				i += end + 4
+				continue
+			}
+		}
+
+		if m := reCounter.FindString(rest); m != "" {
+			i += len(m)
+			continue
+		} else if m := reAttrRef.FindStringSubmatch(rest); m != nil {
+			// Undefined attributes are dropped, as with Asciidoctor's
+			// `attribute-missing=drop`.
+			if value, ok := c.attrs[m[1]]; ok {
+				t.AddAt(value, pos[i])
+			} else if value, ok := builtins[m[1]]; ok {
+				t.AddAt(value, pos[i])
+			}
+			i += len(m[0])
+			continue
+		}
+
+		if m := reAnchor.FindString(rest); m != "" {
+			i += len(m)
+			continue
+		}
+
+		if strings.HasPrefix(rest, "(((") {
+			if end := strings.Index(rest, ")))"); end > 0 {
+				i += end + 3
+				continue
+			}
+		} else if strings.HasPrefix(rest, "((") {
+			if end := strings.Index(rest, "))"); end > 0 {
+				t.Add(rest[2:end], pos[i+2:])
+				i += end + 2
+				continue
+			}
+		}
+
+		if m := reXref.FindStringSubmatchIndex(rest); m != nil {
+			from := len(t.Buf)
+			if m[4] >= 0 && m[5] > m[4] {
+				c.inline(t, rest[m[4]:m[5]], pos[i+m[4]:i+m[5]])
+				t.Mark(markup.Span{Tag: "a"}, from)
+			} else {
+				t.Add(rest[m[2]:m[3]], pos[i+m[2]:])
+				t.Mark(markup.Span{Tag: "code"}, from)
+			}
+			i += m[1]
+			continue
+		}
+
+		if start {
+			if size := c.macro(t, rest, pos[i:]); size > 0 {
+				i += size
+				continue
+			}
+
+			if m := reRoleSpan.FindStringSubmatchIndex(rest); m != nil {
+				// A role or ID applied to formatted text: `[.role]#text#`.
+				if _, _, _, size := c.quote(rest[m[2]:], true); size > 0 {
+					i += m[2]
+					continue
+				}
+			}
+		}
+
+		if tag, from, to, size := c.quote(rest, start); size > 0 {
+			at := len(t.Buf)
+			if tag == "code" {
+				t.Add(rest[from:to], pos[i+from:])
+			} else {
+				c.inline(t, rest[from:to], pos[i+from:i+to])
+			}
+			t.Mark(markup.Span{Tag: tag}, at)
+			i += size
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(rest)
+		t.Add(rest[:size], pos[i:])
+		i += size
+	}
+}
+
+// raw appends passthrough content, which is included as-is.
+func raw(t *markup.Text, s string, pos []int) {
+	if s != "" {
+		from := len(t.Buf)
+		t.Add(s, pos)
+		t.Mark(markup.Span{Raw: true}, from)
+	}
+}
+
+// quote recognizes formatted text (e.g., `*bold*` or `__emphasis__`) at the
+// start of `s`, returning its tag, the bounds of its content, and its size.
+func (c *converter) quote(s string, start bool) (string, int, int, int) {
+	for _, q := range quotes {
+		if !strings.HasPrefix(s, q.mark) {
+			continue
+		}
+
+		size := len(q.mark)
+		switch {
+		case size == 2:
+			if end := strings.Index(s[2:], q.mark); end > 0 {
+				return q.tag, 2, 2 + end, end + 4
+			}
+		case q.mark == "^" || q.mark == "~":
+			end := strings.Index(s[1:], q.mark)
+			if end > 0 && !strings.ContainsAny(s[1:1+end], " \t\n") {
+				return q.tag, 1, 1 + end, end + 2
+			}
+		case start:
+			if end := constrainedEnd(s, q.mark); end > 0 {
+				return q.tag, 1, end, end + 1
+			}
+		}
+		return "", 0, 0, 0
+	}
+	return "", 0, 0, 0
+}
+
+// macro recognizes links and inline macros (e.g., `image:x.png[Alt]`) at the
+// start of `s`, appending them to t and returning their size.
+func (c *converter) macro(t *markup.Text, s string, pos []int) int {
+	from := len(t.Buf)
+	if strings.HasPrefix(s, "<") {
+		if m := reURL.FindString(s[1:]); m != "" && strings.HasPrefix(s[1+len(m):], ">") {
+			t.Add(m, pos[1:])
+			t.Mark(markup.Span{Tag: "a", Literal: true}, from)
+			return len(m) + 2
+		}
+	}
+
+	if m := reURL.FindString(s); m != "" {
+		if strings.HasPrefix(s[len(m):], "[") {
+			if end := matchBracket(s, len(m)); end > 0 {
+				c.linkText(t, s[len(m)+1:end], pos[len(m)+1:end], m, pos)
+				return end + 1
+			}
+		}
+		t.Add(m, pos)
+		t.Mark(markup.Span{Tag: "a", Literal: true}, from)
+		return len(m)
+	}
+
+	if m := reLinkMacro.FindStringSubmatchIndex(s); m != nil {
+		open := m[1] - 1
+		if end := matchBracket(s, open); end > 0 {
+			c.linkText(t, s[open+1:end], pos[open+1:end], s[m[2]:m[3]], pos[m[2]:])
+			return end + 1
+		}
+	}
+
+	m := reInlineMacro.FindStringSubmatchIndex(s)
+	if m == nil {
+		return 0
+	}
+
+	open := m[1] - 1
+	end := matchBracket(s, open)
+	if end < 0 {
+		return 0
+	}
+	name, target, attrs := s[m[2]:m[3]], s[m[4]:m[5]], s[open+1:end]
+	apos := pos[open+1 : end]
+
+	switch name {
+	case "image":
+		if alt := imageAlt(attrs); alt != "" {
+			if idx := strings.Index(attrs, alt); idx >= 0 {
+				t.Add(alt, apos[idx:])
+			} else {
+				t.AddAt(alt, apos[0])
+			}
+		}
+		t.Mark(markup.Span{Tag: "img"}, from)
+	case "footnote", "footnoteref":
+		if name == "footnoteref" {
+			idx := strings.IndexByte(attrs, ',')
+			attrs, apos = attrs[idx+1:], apos[idx+1:]
+		}
+		if attrs != "" {
+			c.inline(t, attrs, apos)
+			t.Mark(markup.Span{Tag: "sup"}, from)
+		}
+	case "xref":
+		if attrs == "" {
+			t.Add(target, pos[m[4]:])
+			t.Mark(markup.Span{Tag: "code"}, from)
+		} else {
+			c.inline(t, attrs, apos)
+			t.Mark(markup.Span{Tag: "a"}, from)
+		}
+	case "indexterm2":
+		t.Add(attrs, apos)
+	case "anchor", "indexterm", "icon":
+	case "kbd", "btn", "menu", "stem", "latexmath", "asciimath":
+		t.Add(s[m[4]:end], pos[m[4]:])
+		t.Mark(markup.Span{Tag: "kbd"}, from)
+	default:
+		if target == "" && !strings.Contains(attrs, " ") {
+			// Not a macro we know about (e.g., "Note:[x]"): treat it as text.
+			return 0
+		}
+		t.Add(s[:end+1], pos)
+		t.Mark(markup.Span{Tag: "code"}, from)
+	}
+
+	return end + 1
+}
+
+// linkText appends the text of a link, dropping any attributes (e.g.,
+// `window=_blank`) and the `^` shorthand for opening a new window.
+//
+// Links without any text are shown as their target, which isn't prose.
+func (c *converter) linkText(t *markup.Text, attrs string, apos []int, target string, tpos []int) {
+	from := len(t.Buf)
+
+	text := attrs
+	if strings.Contains(attrs, "=") {
+		text = splitAttrs(attrs)[0]
+		if strings.Contains(text, "=") {
+			text = ""
+		}
+	}
+
+	lead := len(text) - len(strings.TrimLeft(text, `"`))
+	text = strings.TrimSuffix(strings.Trim(text, `"`), "^")
+	if text == "" {
+		t.Add(target, tpos)
+		t.Mark(markup.Span{Tag: "a", Literal: true}, from)
+		return
+	}
+
+	c.inline(t, text, apos[lead:lead+len(text)])
+	t.Mark(markup.Span{Tag: "a"}, from)
+}
+
+// substitute replaces attribute references in s.
+func (c *converter) substitute(s string) string {
+	if !strings.Contains(s, "{") {
+		return s
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		if m := reAttrRef.FindStringSubmatch(s[i:]); m != nil {
+			if value, ok := c.attrs[m[1]]; ok {
+				sb.WriteString(value)
+			} else if value, ok := builtins[m[1]]; ok {
+				sb.WriteString(value)
+			}
+			i += len(m[0])
+			continue
+		}
+		sb.WriteByte(s[i])
+		i++
+	}
+	return sb.String()
+}
+
+// constrainedEnd returns the index of the closing mark for constrained
+// formatting (e.g., `*bold*`), or -1.
+func constrainedEnd(s, mark string) int {
+	if len(s) < 3 {
+		return -1
+	} else if r, _ := utf8.DecodeRuneInString(s[1:]); unicode.IsSpace(r) {
+		return -1
+	}
+
+	for i := 2; i < len(s); i++ {
+		if !strings.HasPrefix(s[i:], mark) {
+			continue
+		}
+
+		prev, _ := utf8.DecodeLastRuneInString(s[:i])
+		if unicode.IsSpace(prev) {
+			continue
+		}
+
+		next, _ := utf8.DecodeRuneInString(s[i+1:])
+		if i+1 == len(s) || !(unicode.IsLetter(next) || unicode.IsDigit(next) || next == '_') {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// matchBracket returns the index of the `]` matching the `[` at s[open].
+func matchBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isStartBoundary(s string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	return !(unicode.IsLetter(prev) || unicode.IsDigit(prev) || prev == '_' || prev == ':' || prev == ';')
+}
@@ -0,0 +1,204 @@
+package adoc
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/lint/markup"
+)
+
+// list parses an ordered or unordered list starting at `lines[i]`.
+//
+// AsciiDoc nests lists by changing the marker (e.g., `*` then `**`), so an
+// item's body consists of every line up to the next item with the same
+// marker -- nested items included.
+func (c *converter) list(lines []markup.Line, i int, re *regexp.Regexp, tag string) int {
+	marker := re.FindStringSubmatch(lines[i].Text)[1]
+	same := func(line string) bool {
+		m := re.FindStringSubmatch(line)
+		return m != nil && sameMarker(m[1], marker)
+	}
+
+	title := c.title
+	c.resetAttributes()
+
+	c.blockTitle(title)
+	c.Open(tag)
+	defer c.Close()
+
+	n := len(lines)
+	for i < n && same(lines[i].Text) {
+		m := re.FindStringSubmatchIndex(lines[i].Text)
+
+		text := lines[i].From(m[4])
+		if loc := reChecklist.FindStringIndex(text.Text); loc != nil {
+			text = text.From(loc[1])
+		}
+
+		body := []markup.Line{text}
+		j := i + 1
+		for j < n {
+			line := lines[j].TrimRight()
+			if same(line.Text) {
+				break
+			} else if line.Text == "+" {
+				// A list continuation attaches the next block to the item.
+				body = append(body, markup.Line{})
+				j = continuation(lines, j+1, &body)
+				continue
+			} else if markup.IsBlank(line.Text) {
+				next := j
+				for next < n && markup.IsBlank(lines[next].Text) {
+					next++
+				}
+				if next < n && isListItem(lines[next].Text) {
+					body = append(body, markup.Line{})
+					j = next
+					continue
+				}
+				break
+			}
+			body = append(body, line.From(markup.IndentOf(line.Text)))
+			j++
+		}
+
+		c.Open("li")
+		c.blocks(itemBody(body))
+		c.Close()
+		i = j
+	}
+
+	return i
+}
+
+// itemBody separates an item's text from any nested lists that directly
+// follow it, which would otherwise be read as part of its paragraph.
+func itemBody(body []markup.Line) []markup.Line {
+	out := []markup.Line{}
+	for idx, line := range body {
+		if idx > 0 && isListItem(line.Text) && !markup.IsBlank(out[len(out)-1].Text) {
+			out = append(out, markup.Line{})
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// continuation appends the block starting at `lines[j]` to `body`.
+func continuation(lines []markup.Line, j int, body *[]markup.Line) int {
+	if j >= len(lines) {
+		return j
+	}
+
+	line := lines[j].TrimRight()
+	if reBlockAttrs.MatchString(line.Text) || reBlockTitle.MatchString(line.Text) {
+		*body = append(*body, line)
+		j++
+		if j >= len(lines) {
+			return j
+		}
+		line = lines[j].TrimRight()
+	}
+
+	if delim := delimiter(line.Text); delim != "" {
+		end := closing(lines, j, delim)
+		*body = append(*body, lines[j:end]...)
+		return end
+	}
+
+	for j < len(lines) && !markup.IsBlank(lines[j].Text) &&
+		strings.TrimSpace(lines[j].Text) != "+" && !isListItem(lines[j].Text) {
+		*body = append(*body, lines[j])
+		j++
+	}
+	return j
+}
+
+func (c *converter) descriptionList(lines []markup.Line, i int) int {
+	title := c.title
+	c.resetAttributes()
+
+	c.blockTitle(title)
+	c.Open("dl")
+	defer c.Close()
+
+	n := len(lines)
+	for i < n && isDescription(lines[i].TrimRight().Text) {
+		l := lines[i].TrimRight()
+		m := reDescription.FindStringSubmatchIndex(l.Text)
+
+		term := l.From(m[2])
+		term.Text = l.Text[m[2]:m[3]]
+
+		body := []markup.Line{}
+		if m[6] >= 0 && m[7] > m[6] {
+			body = append(body, l.From(m[6]))
+		}
+
+		j := i + 1
+		for j < n {
+			line := lines[j].TrimRight()
+			if isDescription(line.Text) && markup.IndentOf(line.Text) == 0 {
+				break
+			} else if line.Text == "+" {
+				body = append(body, markup.Line{})
+				j = continuation(lines, j+1, &body)
+				continue
+			} else if markup.IsBlank(line.Text) {
+				next := j
+				for next < n && markup.IsBlank(lines[next].Text) {
+					next++
+				}
+				if next < n && len(body) == 0 {
+					// The description may start after a blank line.
+					j = next
+					continue
+				} else if next < n && (isListItem(lines[next].Text) || isDescription(lines[next].Text)) {
+					if !isDescription(lines[next].Text) {
+						body = append(body, markup.Line{})
+					}
+					j = next
+					continue
+				}
+				break
+			}
+			body = append(body, line.From(markup.IndentOf(line.Text)))
+			j++
+		}
+
+		c.text("dt", []markup.Line{term})
+		c.Open("dd")
+		c.blocks(itemBody(body))
+		c.Close()
+		i = j
+	}
+
+	return i
+}
+
+func isListItem(line string) bool {
+	line = strings.TrimRight(line, " \t\r")
+	return reUnordered.MatchString(line) || reOrdered.MatchString(line)
+}
+
+// sameMarker reports whether two list markers belong to the same list.
+func sameMarker(a, b string) bool {
+	if a == b {
+		return true
+	}
+	// Numbered markers (e.g., `1.` and `2.`) are equivalent.
+	kind := func(m string) string {
+		switch {
+		case strings.Trim(m, ".*-") == "":
+			return m
+		case strings.HasSuffix(m, ")"):
+			return ")"
+		case m[0] >= '0' && m[0] <= '9':
+			return "1."
+		case m[0] >= 'a' && m[0] <= 'z':
+			return "a."
+		}
+		return "A."
+	}
+	return kind(a) == kind(b)
+}
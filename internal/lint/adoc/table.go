@@ -0,0 +1,228 @@
+package adoc
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/errata-ai/vale/v3/internal/lint/markup"
+)
+
+// reCellSpec matches the (optional) specifier that precedes a cell's
+// separator -- e.g., the `2+a` in `2+a|cell`.
+var reCellSpec = regexp.MustCompile(`(?:^|\s)((?:\d+\*|\d*\.?\d*\+)?[<^>]?(?:\.[<^>])?[adehlmsv]?)$`)
+
+type cell struct {
+	text  string
+	pos   []int
+	style byte
+	span  int
+}
+
+// table parses a table whose content is `lines` and whose delimiter starts
+// with `sep` (`|`, `,`, `:`, or `!`).
+func (c *converter) table(lines []markup.Line, sep byte, title markup.Line, opts map[string]string) {
+	format := opts["format"]
+	switch {
+	case format == "" && sep == ',':
+		format = "csv"
+	case format == "" && sep == ':':
+		format = "dsv"
+	case format == "":
+		format = "psv"
+	}
+
+	if s := opts["separator"]; s != "" {
+		sep = s[0]
+	} else if format == "csv" {
+		sep = ','
+	} else if format == "dsv" {
+		sep = ':'
+	}
+
+	// Asciidoctor treats the first line as a header if it's followed by a
+	// blank line.
+	first := -1
+	for idx, l := range lines {
+		if !markup.IsBlank(l.Text) {
+			first = idx
+			break
+		}
+	}
+
+	header := false
+	if _, ok := opts["header-option"]; ok || strings.Contains(opts["options"], "header") {
+		header = true
+	} else if _, ok := opts["noheader-option"]; !ok && first >= 0 && first+1 < len(lines) &&
+		markup.IsBlank(lines[first+1].Text) {
+		header = true
+	}
+
+	var cells []cell
+	if format == "psv" {
+		cells = splitCells(lines, sep)
+	} else {
+		cells = readDelimited(lines, sep)
+	}
+
+	cols := columnCount(opts["cols"])
+	if cols == 0 && first >= 0 {
+		if format == "psv" {
+			cols = len(splitCells(lines[first:first+1], sep))
+		} else {
+			cols = len(readDelimited(lines[first:first+1], sep))
+		}
+	}
+	cols = max(cols, 1)
+
+	c.Open("table")
+	defer c.Close()
+
+	if title.Text != "" {
+		c.text("caption", []markup.Line{title})
+	}
+
+	width := 0
+	inHeader := header
+	for _, cl := range cells {
+		if width == 0 {
+			c.Open("tr")
+		}
+
+		tag := "td"
+		if inHeader || cl.style == 'h' {
+			tag = "th"
+		}
+
+		content := markup.Lines(cl.text, cl.pos)
+		switch cl.style {
+		case 'a':
+			c.Open(tag)
+			c.blocks(content)
+			c.Close()
+		case 'l', 'm':
+			c.Pre(content)
+		default:
+			c.text(tag, trimLines(content))
+		}
+
+		width += max(cl.span, 1)
+		if width >= cols {
+			c.Close()
+			width = 0
+			inHeader = false
+		}
+	}
+
+	if width > 0 {
+		c.Close()
+	}
+}
+
+// splitCells splits prefix-separated table content into cells.
+func splitCells(lines []markup.Line, sep byte) []cell {
+	text, pos := markup.Join(lines)
+	cells := []cell{}
+
+	var current *cell
+	var buf strings.Builder
+	var at []int
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if ch == '\\' && i+1 < len(text) && text[i+1] == sep {
+			buf.WriteByte(sep)
+			at = append(at, pos[i+1])
+			i++
+			continue
+		} else if ch != sep {
+			buf.WriteByte(ch)
+			at = append(at, pos[i])
+			continue
+		}
+
+		// The text before the separator may end with the next cell's spec.
+		prev, prevPos := buf.String(), at
+		spec := ""
+		if m := reCellSpec.FindStringSubmatchIndex(prev); m != nil && m[3] > m[2] {
+			spec = prev[m[2]:m[3]]
+			prev, prevPos = prev[:m[2]], prevPos[:m[2]]
+		}
+
+		if current != nil {
+			current.text, current.pos = trimCell(prev, prevPos)
+			cells = append(cells, *current)
+		}
+		current = newCell(spec)
+		buf.Reset()
+		at = nil
+	}
+
+	if current != nil {
+		current.text, current.pos = trimCell(buf.String(), at)
+		cells = append(cells, *current)
+	}
+
+	return cells
+}
+
+// trimCell removes the whitespace surrounding a cell's text.
+func trimCell(s string, pos []int) (string, []int) {
+	trimmed := strings.TrimLeftFunc(s, unicode.IsSpace)
+	pos = pos[len(s)-len(trimmed):]
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	return trimmed, pos[:len(trimmed)]
+}
+
+func newCell(spec string) *cell {
+	cl := cell{span: 1}
+	if spec == "" {
+		return &cl
+	}
+
+	if last := spec[len(spec)-1]; strings.IndexByte("adehlmsv", last) >= 0 {
+		cl.style = last
+	}
+
+	if idx := strings.Index(spec, "+"); idx > 0 {
+		colspan, _, _ := strings.Cut(spec[:idx], ".")
+		if n, err := strconv.Atoi(colspan); err == nil {
+			cl.span = n
+		}
+	}
+
+	return &cl
+}
+
+func readDelimited(lines []markup.Line, sep byte) []cell {
+	s, pos := markup.Join(lines)
+
+	cells := []cell{}
+	for _, record := range markup.ReadCSV(s, pos, rune(sep)) {
+		for _, field := range record {
+			cells = append(cells, cell{text: field.Text, pos: field.Pos, span: 1})
+		}
+	}
+	return cells
+}
+
+// columnCount determines the number of columns from a `cols` attribute, such
+// as "3*" or "1,2a,1".
+func columnCount(cols string) int {
+	cols = strings.Trim(cols, `"`)
+	if cols == "" {
+		return 0
+	}
+
+	count := 0
+	for _, spec := range strings.FieldsFunc(cols, func(r rune) bool { return r == ',' || r == ';' }) {
+		if n, _, found := strings.Cut(strings.TrimSpace(spec), "*"); found {
+			if i, err := strconv.Atoi(n); err == nil {
+				count += i
+				continue
+			}
+		}
+		count++
+	}
+	return count
+}
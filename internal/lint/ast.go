@@ -151,6 +151,10 @@ func (l *Linter) lintScope(f *core.File, state *walker, txt string) error {
 
 			txt = strings.TrimLeft(txt, " ")
 			b := state.block(txt, scope+l.metaScope+f.RealExt)
+			if !match {
+				addHeading(f, tag, txt, b.Line)
+			}
+
 			return l.lintBlock(f, b, state.lines, 0, false)
 		}
 	}
@@ -161,6 +165,32 @@ func (l *Linter) lintScope(f *core.File, state *walker, txt string) error {
 	return l.lintProse(f, b, state.lines)
 }
 
+// addHeading adds a heading, found on the given (0-based) line, to f's
+// outline.
+func addHeading(f *core.File, tag, txt string, line int) {
+	h := core.Heading{
+		Level:  int(tag[1] - '0'),
+		Text:   strings.TrimSpace(txt),
+		Line:   line + 1,
+		Column: 1,
+	}
+
+	if line >= 0 && line < len(f.Lines) {
+		src := f.Lines[line]
+
+		idx := strings.Index(src, h.Text)
+		if fields := strings.Fields(h.Text); idx < 0 && len(fields) > 0 {
+			idx = strings.Index(src, fields[0])
+		}
+
+		if idx >= 0 {
+			h.Column = utf8.RuneCountInString(src[:idx]) + 1
+		}
+	}
+
+	f.Headings = append(f.Headings, h)
+}
+
 func (l *Linter) lintSizedScopes(f *core.File) error {
 	f.ResetComments()
 
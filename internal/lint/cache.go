@@ -0,0 +1,231 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adrg/xdg"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/system"
+)
+
+// cacheVersion is the version of the on-disk cache format; it's part of
+// every key, so bumping it invalidates all existing entries.
+const cacheVersion = 1
+
+// A lintCache stores the alerts of previously-linted files on disk.
+//
+// Entries are keyed on the content of a file, the styles and checks that
+// apply to it, and a hash of everything else that can affect its alerts: the
+// resolved configuration, every file on the `StylesPath` (rules, vocabularies,
+// scripts, etc.), and the Vale executable itself.
+type lintCache struct {
+	dir string
+	key string
+}
+
+type cacheEntry struct {
+	Path   string
+	Alerts []core.Alert
+}
+
+// newLintCache initializes a cache in `root`, which defaults to Vale's
+// directory in the user's XDG cache directory.
+//
+// Entries are grouped by project (i.e., the root configuration file) and
+// configuration hash: whenever the configuration changes, we remove the
+// project's outdated entries.
+func newLintCache(cfg *core.Config, root string) (*lintCache, error) {
+	if root == "" {
+		root = filepath.Join(xdg.CacheHome, "vale", "lint")
+	}
+	root = system.AbsPath(root)
+
+	key, err := configHash(cfg, root)
+	if err != nil {
+		return nil, core.NewE100("cache", err)
+	}
+
+	project := cfg.RootINI
+	if project == "" {
+		project, _ = os.Getwd()
+	}
+	projectDir := filepath.Join(root, hashString(project)[:16])
+
+	entries, _ := os.ReadDir(projectDir)
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != key[:16] {
+			_ = os.RemoveAll(filepath.Join(projectDir, e.Name()))
+		}
+	}
+
+	dir := filepath.Join(projectDir, key[:16])
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return nil, core.NewE100("cache", err)
+	}
+
+	return &lintCache{dir: dir, key: key}, nil
+}
+
+// load replaces f's alerts with their cached values, if there are any.
+func (c *lintCache) load(f *core.File) bool {
+	b, err := os.ReadFile(c.entryPath(f))
+	if err != nil {
+		return false
+	}
+
+	var entry cacheEntry
+	if err = json.Unmarshal(b, &entry); err != nil || entry.Path != f.Path {
+		return false
+	}
+
+	f.Alerts = entry.Alerts
+	return true
+}
+
+// store saves f's alerts.
+//
+// Failing to update the cache isn't an error: the file will simply be linted
+// again next time.
+func (c *lintCache) store(f *core.File) {
+	b, err := json.Marshal(cacheEntry{Path: f.Path, Alerts: f.Alerts})
+	if err != nil {
+		return
+	}
+
+	path := c.entryPath(f)
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+
+	// Write to a temporary file first to avoid leaving a partial entry
+	// behind.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return
+	}
+
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+func (c *lintCache) entryPath(f *core.File) string {
+	h := sha256.New()
+
+	checks := []string{}
+	for name, enabled := range f.Checks {
+		checks = append(checks, fmt.Sprintf("%s=%t", name, enabled))
+	}
+	sort.Strings(checks)
+
+	writeFields(h,
+		c.key,
+		system.AbsPath(f.Path),
+		f.NormedExt,
+		f.Format,
+		strings.Join(f.BaseStyles, ","),
+		strings.Join(checks, ","),
+		strings.Join(f.Lines, ""))
+
+	sum := hex.EncodeToString(h.Sum(nil))
+	return filepath.Join(c.dir, sum[:2], sum+".json")
+}
+
+// configHash computes a hash of everything, other than the file itself, that
+// can change the alerts reported for a file.
+func configHash(cfg *core.Config, cacheDir string) (string, error) {
+	h := sha256.New()
+	writeFields(h, fmt.Sprintf("v%d", cacheVersion))
+
+	resolved, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	writeFields(h, string(resolved))
+
+	if flags := cfg.Flags; flags != nil {
+		writeFields(h, flags.AlertLevel, flags.Filter, flags.InExt,
+			fmt.Sprintf("%t", flags.Simple))
+	}
+
+	for _, path := range cfg.ConfigFiles {
+		if err = hashFile(h, path); err != nil {
+			return "", err
+		}
+	}
+
+	for _, root := range cfg.Paths {
+		if !system.IsDir(root) {
+			continue
+		}
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			} else if d.IsDir() {
+				if path == cacheDir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			writeFields(h, filepath.ToSlash(strings.TrimPrefix(path, root)))
+			return hashFile(h, path)
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// A new version of Vale may report different alerts.
+	if exe, err := os.Executable(); err == nil {
+		if info, err := os.Stat(exe); err == nil {
+			writeFields(h, exe, fmt.Sprint(info.Size()), info.ModTime().String())
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(h hash.Hash, path string) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	_, err = io.Copy(h, fd)
+	writeFields(h)
+
+	return err
+}
+
+func writeFields(h hash.Hash, fields ...string) {
+	for _, f := range fields {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+	h.Write([]byte{'\n'})
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,72 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestLintCache(t *testing.T) {
+	styles := t.TempDir()
+
+	rule := filepath.Join(styles, "Test", "Rule.yml")
+	if err := os.MkdirAll(filepath.Dir(rule), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(rule, []byte("extends: existence\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Paths = []string{styles}
+
+	newFile := func(text string) *core.File {
+		f, ferr := core.NewFileFromText("test.md", text, cfg)
+		if ferr != nil {
+			t.Fatal(ferr)
+		}
+		f.BaseStyles = []string{"Test"}
+		return f
+	}
+
+	dir := t.TempDir()
+	cache, err := newLintCache(cfg, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := newFile("This is a test.\n")
+	if cache.load(f) {
+		t.Fatal("expected a cache miss for a new file")
+	}
+
+	f.Alerts = []core.Alert{{Check: "Test.Rule", Line: 1, Span: []int{11, 14}}}
+	cache.store(f)
+
+	f = newFile("This is a test.\n")
+	if !cache.load(f) || len(f.Alerts) != 1 || f.Alerts[0].Check != "Test.Rule" {
+		t.Fatalf("expected the cached alert to be replayed, got %v", f.Alerts)
+	}
+
+	if cache.load(newFile("This is another test.\n")) {
+		t.Fatal("expected a cache miss after editing the file")
+	}
+
+	if err = os.WriteFile(rule, []byte("extends: substitution\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err = newLintCache(cfg, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cache.load(newFile("This is a test.\n")) {
+		t.Fatal("expected a cache miss after editing a rule")
+	}
+}
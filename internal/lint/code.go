@@ -13,19 +13,68 @@ import (
 	"github.com/errata-ai/vale/v3/internal/nlp"
 )
 
-func updateQueries(f *core.File, blueprints map[string]*core.Blueprint) ([]core.Scope, error) {
-	var found []core.Scope
+// updateQueries returns the scopes of the `Blueprint` that applies to f, if
+// any.
+func updateQueries(f *core.File, cfg *core.Config) []core.Scope {
+	if blueprint := blueprintFor(f, cfg, cfg.Blueprints); blueprint != nil {
+		return blueprint.Scopes
+	}
+	return nil
+}
+
+// blueprintFor returns the blueprint in `blueprints` (keyed by section) that
+// applies to f, if any.
+//
+// As with `BasedOnStyles`, a later section takes precedence over an earlier
+// one when both match.
+func blueprintFor(f *core.File, cfg *core.Config, blueprints map[string]*core.Blueprint) *core.Blueprint {
+	var found *core.Blueprint
+	for _, sec := range cfg.RuleKeys {
+		if pat, ok := cfg.SecToPat[sec]; ok && pat.Match(f.Path) {
+			if blueprint, exists := blueprints[sec]; exists {
+				found = blueprint
+			}
+		}
+	}
+	return found
+}
 
-	for syntax, blueprint := range blueprints {
+// applyBlueprint replaces the default queries of `lang` with those of a
+// blueprint. Scopes of type `string` replace the string-literal queries (and
+// enable them); all others replace the comment queries.
+func applyBlueprint(lang *code.Language, found []core.Scope) bool {
+	var comments, literals []core.Scope
+
+	for _, scope := range found {
+		if scope.Type == "string" {
+			literals = append(literals, scope)
+		} else {
+			comments = append(comments, scope)
+		}
+	}
+
+	if len(comments) > 0 {
+		lang.Queries = comments
+	}
+	if len(literals) > 0 {
+		lang.Strings = literals
+	}
+
+	return len(literals) > 0
+}
+
+// lintsStrings determines if string literals should be linted in `f`.
+func lintsStrings(f *core.File, sections map[string]bool) (bool, error) {
+	enabled := false
+	for syntax, value := range sections {
 		sec, err := glob.Compile(syntax)
 		if err != nil {
-			return nil, err
+			return false, err
 		} else if sec.Match(f.Path) {
-			found = blueprint.Scopes
+			enabled = value
 		}
 	}
-
-	return found, nil
+	return enabled, nil
 }
 
 func (l *Linter) lintCode(f *core.File) error {
@@ -34,25 +83,46 @@ func (l *Linter) lintCode(f *core.File) error {
 		// No tree-sitter grammar available for this file type.
 		return l.lintCodeOld(f)
 	}
+	return l.lintComments(f, lang)
+}
+
+// lintComments lints the comments (and, if enabled, string literals) of f,
+// which is written in lang.
+func (l *Linter) lintComments(f *core.File, lang *code.Language) error {
 	ignored := l.Manager.Config.IgnoredScopes
 
-	found, err := updateQueries(f, l.Manager.Config.Blueprints)
+	withStrings, err := lintsStrings(f, l.Manager.Config.LintStrings)
 	if err != nil {
 		return err
-	} else if len(found) > 0 {
-		lang.Queries = found
+	}
+
+	if found := updateQueries(f, l.Manager.Config); len(found) > 0 {
+		withStrings = applyBlueprint(lang, found) || withStrings
 	}
 
 	comments, err := code.GetComments([]byte(f.Content), lang)
 	if err != nil {
 		return err
 	}
+
+	if withStrings {
+		literals, sErr := code.GetStrings([]byte(f.Content), lang)
+		if sErr != nil {
+			return sErr
+		}
+		comments = append(comments, literals...)
+	}
 	wholeFile := f.Content
 
-	last := 0
+	last := len(f.Alerts)
 	for _, comment := range comments {
+		kind := "comment"
+		if comment.IsString() {
+			kind = "string"
+		}
+
 		l.SetMetaScope(comment.Scope)
-		if core.StringInSlice("comment", ignored) {
+		if core.StringInSlice(kind, ignored) {
 			continue
 		} else if core.StringInSlice(comment.Scope, ignored) {
 			continue
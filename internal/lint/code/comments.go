@@ -48,6 +48,22 @@ func addSourceLine(line string, atEnd bool) string {
 	return line
 }
 
+// flush appends the line comments buffered in tBuf and sBuf to the last
+// joined comment.
+func flush(joined []Comment, tBuf, sBuf *bytes.Buffer) {
+	if tBuf.Len() > 0 {
+		last := joined[len(joined)-1]
+
+		last.Text += addSourceLine(tBuf.String(), false)
+		last.Source += addSourceLine(sBuf.String(), false)
+
+		joined[len(joined)-1] = last
+
+		tBuf.Reset()
+		sBuf.Reset()
+	}
+}
+
 func coalesce(comments []Comment) []Comment {
 	var joined []Comment
 
@@ -56,38 +72,19 @@ func coalesce(comments []Comment) []Comment {
 
 	for i, comment := range comments {
 		if comment.Scope == "text.comment.block" { //nolint:gocritic
+			// NOTE: The buffered line comments belong to the comment before
+			// this one, so we need to flush them first.
+			flush(joined, &tBuf, &sBuf)
 			joined = append(joined, comment)
 		} else if i == 0 || doneMerging(comment, comments[i-1]) {
-			if tBuf.Len() > 0 {
-				// We have comments to merge ...
-				last := joined[len(joined)-1]
-
-				last.Text += addSourceLine(tBuf.String(), false)
-				last.Source += addSourceLine(sBuf.String(), false)
-
-				joined[len(joined)-1] = last
-
-				tBuf.Reset()
-				sBuf.Reset()
-			}
+			flush(joined, &tBuf, &sBuf)
 			joined = append(joined, comment)
 		} else {
 			tBuf.WriteString(addSourceLine(comment.Text, true))
 			sBuf.WriteString(addSourceLine(comment.Source, true))
 		}
 	}
-
-	if tBuf.Len() > 0 {
-		last := joined[len(joined)-1]
-
-		last.Text += addSourceLine(tBuf.String(), false)
-		last.Source += addSourceLine(sBuf.String(), false)
-
-		joined[len(joined)-1] = last
-
-		tBuf.Reset()
-		sBuf.Reset()
-	}
+	flush(joined, &tBuf, &sBuf)
 
 	for i, comment := range joined {
 		joined[i].Text = strings.TrimLeft(comment.Text, " ")
@@ -0,0 +1,19 @@
+package code
+
+import (
+	"regexp"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/smacker/go-tree-sitter/csharp"
+)
+
+func CSharp() *Language {
+	return &Language{
+		Delims:  regexp.MustCompile(`/{2,3}|/\*|\*/`),
+		Parser:  csharp.GetLanguage(),
+		Queries: []core.Scope{{Name: "", Expr: "(comment) @comment", Type: ""}},
+		Padding: func(s string) int {
+			return computePadding(s, []string{"/*", "//", "///"})
+		},
+	}
+}
@@ -7,11 +7,20 @@ import (
 	"github.com/smacker/go-tree-sitter/golang"
 )
 
+// goStrings are the string literals that are likely to be user-facing:
+// arguments, values, and the values of composite literals.
+var goStrings = []core.Scope{
+	{Name: "", Expr: `(argument_list [(interpreted_string_literal) (raw_string_literal)] @string)`, Type: ""},
+	{Name: "", Expr: `(expression_list [(interpreted_string_literal) (raw_string_literal)] @string)`, Type: ""},
+	{Name: "", Expr: `(keyed_element (literal_element) (literal_element [(interpreted_string_literal) (raw_string_literal)] @string))`, Type: ""},
+}
+
 func Go() *Language {
 	return &Language{
 		Delims:  regexp.MustCompile(`//|/\*|\*/`),
 		Parser:  golang.GetLanguage(),
 		Queries: []core.Scope{{Name: "", Expr: "(comment) @comment", Type: ""}},
 		Padding: cStyle,
+		Strings: goStrings,
 	}
 }
@@ -7,6 +7,24 @@ import (
 	"github.com/smacker/go-tree-sitter/javascript"
 )
 
+// jsStrings are the string literals that are likely to be user-facing in
+// JavaScript and TypeScript: arguments, values, and return values.
+var jsStrings = []core.Scope{
+	{Name: "", Expr: `(arguments (string (string_fragment) @string))`, Type: ""},
+	{Name: "", Expr: `(variable_declarator value: (string (string_fragment) @string))`, Type: ""},
+	{Name: "", Expr: `(assignment_expression right: (string (string_fragment) @string))`, Type: ""},
+	{Name: "", Expr: `(pair value: (string (string_fragment) @string))`, Type: ""},
+	{Name: "", Expr: `(return_statement (string (string_fragment) @string))`, Type: ""},
+}
+
+// jsxStrings adds the text of JSX elements to `jsStrings`.
+func jsxStrings() []core.Scope {
+	return append([]core.Scope{
+		{Name: "", Expr: `(jsx_text) @string`, Type: ""},
+		{Name: "", Expr: `(jsx_expression (string (string_fragment) @string))`, Type: ""},
+	}, jsStrings...)
+}
+
 func JavaScript() *Language {
 	return &Language{
 		Delims: regexp.MustCompile(`//|/\*\*?|\*/`),
@@ -14,5 +32,6 @@ func JavaScript() *Language {
 		//Cutset:  " *",
 		Queries: []core.Scope{{Name: "", Expr: "(comment) @comment", Type: ""}},
 		Padding: cStyle,
+		Strings: jsxStrings(),
 	}
 }
@@ -0,0 +1,17 @@
+package code
+
+import (
+	"regexp"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/smacker/go-tree-sitter/kotlin"
+)
+
+func Kotlin() *Language {
+	return &Language{
+		Delims:  regexp.MustCompile(`//|/\*\*?|\*/`),
+		Parser:  kotlin.GetLanguage(),
+		Queries: []core.Scope{{Name: "", Expr: "[(line_comment) (multiline_comment)] @comment", Type: ""}},
+		Padding: cStyle,
+	}
+}
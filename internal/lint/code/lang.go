@@ -12,13 +12,18 @@ type padding func(string) int
 
 // Language represents a supported programming language.
 //
-// NOTE: What about haskell, less, perl, php, powershell, r, sass, swift?
+// NOTE: Languages without a tree-sitter grammar (e.g., Haskell, Perl, and
+// PowerShell) fall back to `core.CommentsByNormedExt`.
 type Language struct {
 	Delims  *regexp.Regexp
 	Parser  *sitter.Language
 	Queries []core.Scope
 	Cutset  string
 	Padding padding
+
+	// Strings are the queries for user-facing string literals (e.g., error
+	// messages or labels), which are only linted when enabled.
+	Strings []core.Scope
 }
 
 // GetLanguageFromExt returns a Language based on the given file extension.
@@ -50,6 +55,24 @@ func GetLanguageFromExt(ext string) (*Language, error) {
 		return YAML(), nil
 	case ".css":
 		return CSS(), nil
+	case ".java":
+		return Java(), nil
+	case ".cs":
+		return CSharp(), nil
+	case ".php":
+		return PHP(), nil
+	case ".lua":
+		return Lua(), nil
+	case ".sh":
+		return Bash(), nil
+	case ".swift":
+		return Swift(), nil
+	case ".kt":
+		return Kotlin(), nil
+	case ".scala":
+		return Scala(), nil
+	case ".sql":
+		return SQL(), nil
 	default:
 		return nil, fmt.Errorf("unsupported extension: '%s'", ext)
 	}
@@ -0,0 +1,21 @@
+package code
+
+import (
+	"regexp"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/smacker/go-tree-sitter/lua"
+)
+
+func Lua() *Language {
+	return &Language{
+		Delims: regexp.MustCompile(`-{2,3}(?:\[=*\[)?|\]=*\]`),
+		Parser: lua.GetLanguage(),
+		// NOTE: This grammar parses `---` comments as EmmyLua annotations, of
+		// which we only want the free-form text.
+		Queries: []core.Scope{{Name: "", Expr: "[(comment) (emmy_header)] @comment", Type: ""}},
+		Padding: func(s string) int {
+			return computePadding(s, []string{"--", "---", "--[[", "---[["})
+		},
+	}
+}
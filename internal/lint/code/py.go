@@ -7,6 +7,15 @@ import (
 	"github.com/smacker/go-tree-sitter/python"
 )
 
+// pyStrings are the string literals that are likely to be user-facing:
+// arguments, values, and return values (but not docstrings).
+var pyStrings = []core.Scope{
+	{Name: "", Expr: `(argument_list (string (string_content) @string))`, Type: ""},
+	{Name: "", Expr: `(keyword_argument value: (string (string_content) @string))`, Type: ""},
+	{Name: "", Expr: `(assignment right: (string (string_content) @string))`, Type: ""},
+	{Name: "", Expr: `(return_statement (string (string_content) @string))`, Type: ""},
+}
+
 func Python() *Language {
 	return &Language{
 		Delims: regexp.MustCompile(`#|"""|'''`),
@@ -28,5 +37,6 @@ func Python() *Language {
 		Padding: func(s string) int {
 			return computePadding(s, []string{"#", `"""`, "'''"})
 		},
+		Strings: pyStrings,
 	}
 }
@@ -33,6 +33,47 @@ func (qe *QueryEngine) run(meta string, q *sitter.Query, source []byte) []Commen
 		meta = "." + meta
 	}
 
+	for _, c := range qe.captures(q, source) {
+		rText, line, offset := skipWhitespace(c.Node, source)
+		cText := qe.lang.Delims.ReplaceAllString(rText, "")
+
+		scope := "text.comment" + meta + ".line"
+		if strings.Count(cText, "\n") > 1 {
+			scope = "text.comment" + meta + ".block"
+		}
+
+		// NOTE: Some grammars (e.g., Rust) include the trailing newline in
+		// line comments, so it doesn't count as a second line.
+		if strings.Contains(strings.TrimSuffix(cText, "\n"), "\n") {
+			buf := bytes.Buffer{}
+			for _, line := range strings.Split(cText, "\n") {
+				buf.WriteString(strings.TrimLeft(line, qe.cutset))
+				buf.WriteString("\n")
+			}
+
+			cText = buf.String()
+		}
+
+		comments = append(comments, Comment{
+			Line:   line,
+			Offset: offset,
+			Scope:  scope,
+			Text:   cText,
+			Source: rText,
+		})
+	}
+
+	return comments
+}
+
+// captures returns the nodes captured by `q`.
+//
+// Captures whose names start with an underscore (e.g., `@_func`) are only
+// used for filtering -- e.g., `(#eq? @_func "gettext")` -- so they're left
+// out.
+func (qe *QueryEngine) captures(q *sitter.Query, source []byte) []sitter.QueryCapture {
+	var captures []sitter.QueryCapture
+
 	qc := sitter.NewQueryCursor()
 	qc.Exec(q, qe.tree.RootNode())
 
@@ -44,31 +85,35 @@ func (qe *QueryEngine) run(meta string, q *sitter.Query, source []byte) []Commen
 
 		m = qc.FilterPredicates(m, source)
 		for _, c := range m.Captures {
-			rText := c.Node.Content(source)
-			cText := qe.lang.Delims.ReplaceAllString(rText, "")
-
-			scope := "text.comment" + meta + ".line"
-			if strings.Count(cText, "\n") > 1 {
-				scope = "text.comment" + meta + ".block"
+			if !strings.HasPrefix(q.CaptureNameForId(c.Index), "_") {
+				captures = append(captures, c)
+			}
+		}
+	}
 
-				buf := bytes.Buffer{}
-				for _, line := range strings.Split(cText, "\n") {
-					buf.WriteString(strings.TrimLeft(line, qe.cutset))
-					buf.WriteString("\n")
-				}
+	return captures
+}
 
-				cText = buf.String()
-			}
+// skipWhitespace returns the content of `node` along with its (1-based) line
+// and (0-based) byte offset.
+//
+// NOTE: Some grammars (e.g., Lua) include the whitespace that precedes a
+// comment in its node, so we skip over it.
+func skipWhitespace(node *sitter.Node, source []byte) (string, int, int) {
+	text := node.Content(source)
+	line := int(node.StartPoint().Row) + 1
+	offset := int(node.StartPoint().Column)
 
-			comments = append(comments, Comment{
-				Line:   int(c.Node.StartPoint().Row) + 1,
-				Offset: int(c.Node.StartPoint().Column),
-				Scope:  scope,
-				Text:   cText,
-				Source: rText,
-			})
+	if trimmed := strings.TrimLeft(text, " \t\r\n"); trimmed != text {
+		lead := text[:len(text)-len(trimmed)]
+		if n := strings.Count(lead, "\n"); n > 0 {
+			line += n
+			offset = len(lead) - strings.LastIndex(lead, "\n") - 1
+		} else {
+			offset += len(lead)
 		}
+		text = trimmed
 	}
 
-	return comments
+	return text, line, offset
 }
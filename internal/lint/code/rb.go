@@ -7,6 +7,13 @@ import (
 	"github.com/smacker/go-tree-sitter/ruby"
 )
 
+// rbStrings are the string literals that are likely to be user-facing:
+// arguments (including those of `return` and `raise`) and values.
+var rbStrings = []core.Scope{
+	{Name: "", Expr: `(argument_list (string (string_content) @string))`, Type: ""},
+	{Name: "", Expr: `(assignment right: (string (string_content) @string))`, Type: ""},
+}
+
 func Ruby() *Language {
 	return &Language{
 		Delims:  regexp.MustCompile(`#|=begin|=end`),
@@ -15,5 +22,6 @@ func Ruby() *Language {
 		Padding: func(s string) int {
 			return computePadding(s, []string{"#", `=begin`, `=end`})
 		},
+		Strings: rbStrings,
 	}
 }
@@ -7,6 +7,14 @@ import (
 	"github.com/smacker/go-tree-sitter/rust"
 )
 
+// rsStrings are the string literals that are likely to be user-facing:
+// arguments (including those of macros such as `println!`) and values.
+var rsStrings = []core.Scope{
+	{Name: "", Expr: `(arguments (string_literal (string_content) @string))`, Type: ""},
+	{Name: "", Expr: `(token_tree (string_literal (string_content) @string))`, Type: ""},
+	{Name: "", Expr: `(let_declaration value: (string_literal (string_content) @string))`, Type: ""},
+}
+
 func Rust() *Language {
 	return &Language{
 		Delims:  regexp.MustCompile(`/{2,3}!?`),
@@ -15,5 +23,6 @@ func Rust() *Language {
 		Padding: func(s string) int {
 			return computePadding(s, []string{"//", "//!", "///"})
 		},
+		Strings: rsStrings,
 	}
 }
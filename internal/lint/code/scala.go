@@ -0,0 +1,17 @@
+package code
+
+import (
+	"regexp"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/smacker/go-tree-sitter/scala"
+)
+
+func Scala() *Language {
+	return &Language{
+		Delims:  regexp.MustCompile(`//|/\*\*?|\*/`),
+		Parser:  scala.GetLanguage(),
+		Queries: []core.Scope{{Name: "", Expr: "[(comment) (block_comment)] @comment", Type: ""}},
+		Padding: cStyle,
+	}
+}
@@ -0,0 +1,22 @@
+package code
+
+import (
+	"regexp"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/smacker/go-tree-sitter/bash"
+)
+
+func Bash() *Language {
+	return &Language{
+		Delims: regexp.MustCompile(`#`),
+		Parser: bash.GetLanguage(),
+		Queries: []core.Scope{
+			// Skip the shebang (e.g., `#!/bin/bash`).
+			{Name: "", Expr: `((comment) @comment (#not-match? @comment "^#!"))`, Type: ""},
+		},
+		Padding: func(s string) int {
+			return computePadding(s, []string{"#"})
+		},
+	}
+}
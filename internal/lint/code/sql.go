@@ -0,0 +1,20 @@
+package code
+
+import (
+	"regexp"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/smacker/go-tree-sitter/sql"
+)
+
+func SQL() *Language {
+	return &Language{
+		Delims: regexp.MustCompile(`--|/\*|\*/`),
+		Parser: sql.GetLanguage(),
+		// NOTE: `marginalia` is this grammar's name for `/* ... */` comments.
+		Queries: []core.Scope{{Name: "", Expr: "[(comment) (marginalia)] @comment", Type: ""}},
+		Padding: func(s string) int {
+			return computePadding(s, []string{"--", "/*"})
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package code
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// quotes matches the opening delimiter of a string literal, including any
+// prefix (e.g., Python's `f"..."` or C#'s `@"..."`).
+var quotes = regexp.MustCompile("^[A-Za-z@$]{0,2}(\"\"\"|'''|\"|'|`)")
+
+// GetStrings returns all string literals matched by `lang.Strings`.
+//
+// Unlike comments, string literals are never merged: each one is returned
+// as-is, without its quotes, and scoped as `text.string[.<name>]`.
+func GetStrings(source []byte, lang *Language) ([]Comment, error) {
+	var found []Comment
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(lang.Parser)
+
+	tree, err := parser.ParseCtx(context.Background(), nil, source)
+	if err != nil {
+		return found, err
+	}
+	engine := NewQueryEngine(tree, lang)
+
+	seen := map[[2]int]bool{}
+	for _, query := range lang.Strings {
+		q, qErr := sitter.NewQuery([]byte(query.Expr), lang.Parser)
+		if qErr != nil {
+			return found, qErr
+		}
+
+		for _, s := range engine.runStrings(query.Name, q, source) {
+			// A literal may be matched by more than one query.
+			key := [2]int{s.Line, s.Offset}
+			if !seen[key] {
+				seen[key] = true
+				found = append(found, s)
+			}
+		}
+	}
+
+	sort.SliceStable(found, func(p, q int) bool {
+		if found[p].Line == found[q].Line {
+			return found[p].Offset < found[q].Offset
+		}
+		return found[p].Line < found[q].Line
+	})
+
+	return found, nil
+}
+
+func (qe *QueryEngine) runStrings(meta string, q *sitter.Query, source []byte) []Comment {
+	var found []Comment
+
+	scope := "text.string"
+	if meta != "" {
+		scope += "." + meta
+	}
+
+	for _, c := range qe.captures(q, source) {
+		text, line, offset := skipWhitespace(c.Node, source)
+
+		skipped := 0
+		if delimited(c.Node) {
+			text, skipped = unquote(text)
+		}
+
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		found = append(found, Comment{
+			Line:   line,
+			Offset: offset + skipped,
+			Scope:  scope,
+			Text:   text,
+			Source: text,
+		})
+	}
+
+	return found
+}
+
+// IsString reports whether `c` is a string literal rather than a comment.
+func (c Comment) IsString() bool {
+	return c.Scope == "text.string" || strings.HasPrefix(c.Scope, "text.string.")
+}
+
+// delimited reports whether `node` includes its delimiters -- e.g., Go's
+// `interpreted_string_literal` does while Python's `string_content` doesn't.
+func delimited(node *sitter.Node) bool {
+	kind := node.Type()
+	if strings.HasSuffix(kind, "_content") || strings.HasSuffix(kind, "_fragment") {
+		return false
+	}
+	return strings.Contains(kind, "string")
+}
+
+// unquote removes the delimiters from a string literal, returning its content
+// and the length of its opening delimiter.
+func unquote(s string) (string, int) {
+	m := quotes.FindStringSubmatch(s)
+	if m == nil || len(s) < len(m[0])+len(m[1]) || !strings.HasSuffix(s, m[1]) {
+		return s, 0
+	}
+	return s[len(m[0]) : len(s)-len(m[1])], len(m[0])
+}
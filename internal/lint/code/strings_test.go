@@ -0,0 +1,54 @@
+package code
+
+import (
+	"testing"
+)
+
+func TestStrings(t *testing.T) {
+	source := []byte("package main\n\n" +
+		"import \"fmt\"\n\n" +
+		"// Not a string.\n" +
+		"func main() {\n" +
+		"\tfmt.Println(\"Hello, world!\", `raw`)\n" +
+		"}\n")
+
+	found, err := GetStrings(source, Go())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Comment{
+		{Text: "Hello, world!", Source: "Hello, world!", Line: 7, Offset: 14, Scope: "text.string"},
+		{Text: "raw", Source: "raw", Line: 7, Offset: 31, Scope: "text.string"},
+	}
+
+	if len(found) != len(expected) {
+		t.Fatalf("expected %d strings, got %s", len(expected), toJSON(found))
+	}
+
+	for i, s := range found {
+		if s != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], s)
+		}
+	}
+}
+
+func TestUnquote(t *testing.T) {
+	cases := map[string]string{
+		`"text"`:           "text",
+		"`text`":           "text",
+		`f"text"`:          "text",
+		`@"text"`:          "text",
+		`"""text"""`:       "text",
+		`text`:             "text",
+		`"unterminated`:    `"unterminated`,
+		`'mixed"`:          `'mixed"`,
+		`r"with 'quotes'"`: "with 'quotes'",
+	}
+
+	for input, expected := range cases {
+		if got, _ := unquote(input); got != expected {
+			t.Errorf("unquote(%s) = %s, expected %s", input, got, expected)
+		}
+	}
+}
@@ -13,5 +13,6 @@ func TypeScript() *Language {
 		Parser:  typescript.GetLanguage(),
 		Queries: []core.Scope{{Name: "", Expr: "(comment) @comment", Type: ""}},
 		Padding: cStyle,
+		Strings: jsStrings,
 	}
 }
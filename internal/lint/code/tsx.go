@@ -13,5 +13,6 @@ func Tsx() *Language {
 		Parser:  tsx.GetLanguage(),
 		Queries: []core.Scope{{Name: "", Expr: "(comment) @comment", Type: ""}},
 		Padding: cStyle,
+		Strings: jsxStrings(),
 	}
 }
@@ -5,27 +5,23 @@ import (
 	"strings"
 
 	"github.com/errata-ai/vale/v3/internal/core"
-	"github.com/errata-ai/vale/v3/internal/glob"
 )
 
 func (l *Linter) lintData(f *core.File) error {
-	for syntax, blueprint := range l.Manager.Config.Blueprints {
-		sec, err := glob.Compile(syntax)
-		if err != nil {
-			return err
-		} else if sec.Match(f.Path) {
-			found, berr := blueprint.Apply(f)
-			if berr != nil {
-				return core.NewE201FromTarget(
-					berr.Error(),
-					fmt.Sprintf("Blueprint = %s", blueprint),
-					l.Manager.Config.RootINI,
-				)
-			}
-			return l.lintScopedValues(f, found)
-		}
+	blueprint := blueprintFor(f, l.Manager.Config, l.Manager.Config.Blueprints)
+	if blueprint == nil {
+		return nil
+	}
+
+	found, err := blueprint.Apply(f)
+	if err != nil {
+		return core.NewE201FromTarget(
+			err.Error(),
+			fmt.Sprintf("Blueprint = %s", blueprint),
+			l.Manager.Config.RootINI,
+		)
 	}
-	return nil
+	return l.lintScopedValues(f, found)
 }
 
 func (l *Linter) lintScopedValues(f *core.File, values []core.ScopedValues) error {
@@ -33,9 +33,18 @@ func adjustAlerts(alerts []core.Alert, last int, comment code.Comment, lang *cod
 		if i >= last {
 			line := findLine(comment.Source, alerts[i].Line)
 
-			padding := lang.Padding(line)
-			if strings.HasPrefix(line, " ") {
-				padding += leadingSpaces(line, comment.Offset)
+			padding := 0
+			if comment.IsString() {
+				// String literals are linted exactly as they appear, so
+				// only their first line is offset.
+				if alerts[i].Line > 1 {
+					padding = -comment.Offset
+				}
+			} else {
+				padding = lang.Padding(line)
+				if strings.HasPrefix(line, " ") {
+					padding += leadingSpaces(line, comment.Offset)
+				}
 			}
 
 			alerts[i].Line += comment.Line - 1
@@ -58,11 +67,10 @@ func (l *Linter) lintFragments(f *core.File) error {
 		return err
 	}
 
-	found, err := updateQueries(f, l.Manager.Config.Blueprints)
-	if err != nil {
-		return err
-	} else if len(found) > 0 {
-		lang.Queries = found
+	if found := updateQueries(f, l.Manager.Config); len(found) > 0 {
+		// NOTE: Fragments only come from comments, so any string scopes
+		// are unused here.
+		applyBlueprint(lang, found)
 	}
 
 	comments, err := code.GetComments([]byte(f.Content), lang)
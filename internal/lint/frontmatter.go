@@ -0,0 +1,159 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+// lintFrontMatter lints the fields of f's front matter selected by the
+// `FrontMatter` blueprint that applies to it, if any.
+//
+// Each selected value is scoped as `text.frontmatter.<name>`, where `name`
+// is the name of the blueprint scope that selected it; everything else is
+// ignored. Front matter that can't be parsed is reported as a
+// `Vale.FrontMatter` error.
+func (l *Linter) lintFrontMatter(f *core.File) error {
+	blueprint := blueprintFor(f, l.Manager.Config, l.Manager.Config.FrontMatter)
+	if blueprint == nil {
+		return nil
+	}
+
+	loc := reFrontMatter.FindStringSubmatchIndex(f.Content)
+	if loc == nil {
+		return nil
+	}
+
+	ext := ".yml"
+	if strings.HasPrefix(f.Content, "+++") {
+		ext = ".toml"
+	}
+
+	value, err := core.ParseData([]byte(f.Content[loc[2]:loc[3]]), ext)
+	if err != nil {
+		// A malformed header shouldn't stop us from linting the rest of the
+		// run (or even the rest of this file), so we report it in place.
+		f.AddLocatedAlert(core.Alert{
+			Check:    "Vale.FrontMatter",
+			Severity: "error",
+			Message:  fmt.Sprintf("Unable to parse front matter: %s", err),
+			Match:    f.Content[:3],
+			Line:     1,
+			Span:     []int{1, 3},
+		})
+		return nil
+	}
+
+	found, err := blueprint.Select(value, true)
+	if err != nil {
+		return core.NewE201FromTarget(
+			err.Error(),
+			fmt.Sprintf("FrontMatter = %s", blueprint),
+			l.Manager.Config.RootINI,
+		)
+	}
+
+	w := newMdWalker(l, f, []byte(f.Content), nil)
+	for i, match := range found {
+		name := match.Scope
+		if name == "" {
+			name = strings.TrimPrefix(blueprint.Scopes[i].Expr, ".")
+		}
+		scope := "text.frontmatter." + name + l.metaScope + f.RealExt
+
+		// Values are located in order, after the key that holds them, so
+		// that repeated values are attributed to each of their occurrences.
+		cursor := keyOffset(f.Content[:loc[3]], blueprint.Scopes[i].Expr, loc[2])
+		for _, v := range match.Values {
+			t := mapValue(f.Content[:loc[3]], v, cursor)
+			if len(t.buf) == 0 {
+				continue
+			}
+			cursor = t.last() + 1
+
+			b := nlp.NewLinedBlock("", string(t.buf), scope, w.line(t.pos[0])-1, nil)
+			if err = w.prose(b, t); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// keyOffset returns the offset in src just past the key selected by the
+// dasel expression expr, starting the search at the given offset.
+//
+// Nested keys (e.g., `params.title`) are located one after the other;
+// functions and indexes are skipped. If a key can't be found, we fall back to
+// the last offset we could find.
+func keyOffset(src, expr string, offset int) int {
+	for _, part := range strings.Split(expr, ".") {
+		if idx := strings.IndexByte(part, '['); idx >= 0 {
+			part = part[:idx]
+		}
+		if part == "" || strings.Contains(part, "(") {
+			continue
+		}
+
+		re := regexp.MustCompile(`(?m)^[ \t]*["']?` + regexp.QuoteMeta(part) + `["']?[ \t]*[:=]`)
+		if m := re.FindStringIndex(src[offset:]); m != nil {
+			offset += m[1]
+		}
+	}
+	return offset
+}
+
+// mapValue maps the parsed value v back to its location in src, starting the
+// search at the given offset.
+//
+// Since the source may quote, escape, or fold the value, we locate it one
+// word at a time: the whitespace between two words is attributed to the end
+// of the first.
+func mapValue(src, v string, offset int) *mdText {
+	t := &mdText{}
+
+	v = strings.TrimSpace(v)
+	if idx := strings.Index(src[offset:], v); idx >= 0 && v != "" {
+		// The common case: a plain, single-line value.
+		t.add(v, 0)
+		for j := range t.pos {
+			t.pos[j] = offset + idx + j
+		}
+		return t
+	}
+
+	for i := 0; i < len(v); {
+		if isSpace(v[i]) {
+			t.add(v[i:i+1], t.last())
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(v) && !isSpace(v[end]) {
+			end++
+		}
+		word := v[i:end]
+
+		idx := strings.Index(src[offset:], word)
+		if idx < 0 {
+			// The word was escaped in the source, so we can only attribute it
+			// to where we are.
+			t.add(word, offset)
+		} else {
+			start := offset + idx
+			for j := 0; j < len(word); j++ {
+				t.add(word[j:j+1], start+j)
+			}
+			offset = start + len(word)
+		}
+
+		i = end
+	}
+
+	return t
+}
@@ -1,11 +1,13 @@
 package lint
 
 import (
+	"context"
 	"errors"
 	"io/fs"
 	"net/http"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/remeh/sizedwaitgroup"
 
@@ -24,6 +26,11 @@ type Linter struct {
 	HasDir    bool
 	nonGlobal bool
 	metaScope string
+	cache     *lintCache
+	profile   *Profile
+
+	// ctx is the context of the current `LintStringContext` call, if any.
+	ctx context.Context
 }
 
 type lintResult struct {
@@ -38,11 +45,22 @@ func NewLinter(cfg *core.Config) (*Linter, error) {
 	globalStyles := len(cfg.GBaseStyles)
 	globalChecks := len(cfg.GChecks)
 
-	return &Linter{
+	linter := &Linter{
 		Manager: mgr,
+		ctx:     context.Background(),
 
 		client:    http.DefaultClient,
-		nonGlobal: globalStyles+globalChecks == 0}, err
+		nonGlobal: globalStyles+globalChecks == 0}
+
+	if err == nil && cfg.Flags != nil && cfg.Flags.Cache {
+		linter.cache, err = newLintCache(cfg, cfg.Flags.CacheDir)
+	}
+
+	if cfg.Flags != nil && cfg.Flags.Profile {
+		linter.profile = newProfile()
+	}
+
+	return linter, err
 }
 
 // Transform applies the configured transformations to text and returns the
@@ -65,6 +83,38 @@ func (l *Linter) Transform(f *core.File) (string, error) {
 // LintString src according to its format.
 func (l *Linter) LintString(src string) ([]*core.File, error) {
 	linted := l.lintFile(src)
+	if linted.err == nil {
+		l.Manager.ResolveCrossFile([]*core.File{linted.file})
+	}
+	return []*core.File{linted.file}, linted.err
+}
+
+// LintStringWithPath lints src as if it were the content of the file located
+// at `path`.
+//
+// The path determines the file's format and which sections of the
+// configuration apply to it, but it doesn't need to exist on disk.
+func (l *Linter) LintStringWithPath(src, path string) ([]*core.File, error) {
+	return l.LintStringContext(context.Background(), src, path)
+}
+
+// LintStringContext is like `LintStringWithPath`, but it stops linting (and
+// returns the context's error) once `ctx` is canceled.
+//
+// Cancellation is checked between blocks, so a rule that's already running
+// is allowed to finish.
+func (l *Linter) LintStringContext(ctx context.Context, src, path string) ([]*core.File, error) {
+	l.ctx = ctx
+	defer func() { l.ctx = context.Background() }()
+
+	file, err := core.NewFileFromText(path, src, l.Manager.Config)
+	if err != nil {
+		return []*core.File{}, err
+	}
+	linted := l.lintDocument(file)
+	if linted.err == nil {
+		l.Manager.ResolveCrossFile([]*core.File{linted.file})
+	}
 	return []*core.File{linted.file}, linted.err
 }
 
@@ -110,6 +160,10 @@ func (l *Linter) Lint(input []string, pat string) ([]*core.File, error) {
 		}
 	}
 
+	// Rules with `crossfile: true` can only be resolved once every file has
+	// been linted.
+	l.Manager.ResolveCrossFile(linted)
+
 	return linted, nil
 }
 
@@ -166,12 +220,30 @@ func (l *Linter) lintFiles(done <-chan core.File, root string) (<-chan lintResul
 // lintFile creates a new `File` from the path `src` and selects a linter based
 // on its format.
 func (l *Linter) lintFile(src string) lintResult {
-	var err error
-
 	file, err := core.NewFile(src, l.Manager.Config)
 	if err != nil {
 		return lintResult{err: err}
-	} else if len(file.Checks) == 0 && len(file.BaseStyles) == 0 {
+	}
+
+	if l.cache == nil {
+		return l.lintDocument(file)
+	} else if l.cache.load(file) {
+		return lintResult{file: file}
+	}
+
+	result := l.lintDocument(file)
+	if result.err == nil {
+		l.cache.store(file)
+	}
+	return result
+}
+
+// lintDocument selects a linter based on the format of an already-initialized
+// `File`.
+func (l *Linter) lintDocument(file *core.File) lintResult {
+	var err error
+
+	if len(file.Checks) == 0 && len(file.BaseStyles) == 0 {
 		if len(l.Manager.Config.GBaseStyles) == 0 && len(l.Manager.Config.GChecks) == 0 {
 			// There's nothing to do; bail early.
 			return lintResult{file: file}
@@ -187,7 +259,14 @@ func (l *Linter) lintFile(src string) lintResult {
 	// we actually have a blueprint to apply.
 	hasBlueprints := len(l.Manager.Config.Blueprints) > 0
 
+	start := time.Now()
 	if file.Format == "markup" && !simple { //nolint:gocritic
+		// NOTE: Front matter is linted up front since some formats (e.g.,
+		// Markdown) modify the file's content.
+		if err = l.lintFrontMatter(file); err != nil {
+			return lintResult{file, err}
+		}
+
 		switch file.NormedExt {
 		case ".adoc":
 			err = l.lintADoc(file)
@@ -205,6 +284,8 @@ func (l *Linter) lintFile(src string) lintResult {
 			err = l.lintHTML(file)
 		case ".org":
 			err = l.lintOrg(file)
+		case ".ipynb":
+			err = l.lintNotebook(file)
 		}
 	} else if file.Format == "data" && !simple && hasBlueprints {
 		err = l.lintData(file)
@@ -228,6 +309,7 @@ func (l *Linter) lintFile(src string) lintResult {
 		raw := nlp.NewBlock("", strings.Join(file.Lines, ""), "raw"+file.RealExt)
 		err = l.lintBlock(file, raw, len(file.Lines), 0, true)
 	}
+	l.profile.recordFormat(file, profileFormat(file, simple), time.Since(start))
 
 	return lintResult{file, err}
 }
@@ -266,6 +348,18 @@ func (l *Linter) lintLines(f *core.File) error {
 }
 
 func (l *Linter) lintBlock(f *core.File, blk nlp.Block, lines, pad int, lookup bool) error {
+	return l.runBlock(f, blk, func(a core.Alert) {
+		f.AddAlert(a, blk, lines, pad, lookup)
+	})
+}
+
+// runBlock runs every applicable rule against blk, passing each resulting
+// alert to add.
+func (l *Linter) runBlock(f *core.File, blk nlp.Block, add func(core.Alert)) error {
+	if err := l.ctx.Err(); err != nil {
+		return err
+	}
+
 	f.ChkToCtx = make(map[string]string)
 	for name, chk := range l.Manager.Rules() {
 		if !l.shouldRun(name, f, chk, blk) {
@@ -274,7 +368,9 @@ func (l *Linter) lintBlock(f *core.File, blk nlp.Block, lines, pad int, lookup b
 
 		info := chk.Fields()
 
+		start := time.Now()
 		alerts, err := chk.Run(blk, f, l.Manager.Config)
+		l.profile.recordRule(f, name, time.Since(start), len(alerts))
 		if err != nil {
 			return err
 		}
@@ -283,7 +379,7 @@ func (l *Linter) lintBlock(f *core.File, blk nlp.Block, lines, pad int, lookup b
 				continue
 			}
 			core.FormatAlert(&alerts[i], info.Limit, info.Level, name)
-			f.AddAlert(alerts[i], blk, lines, pad, lookup)
+			add(alerts[i])
 		}
 	}
 
@@ -2,6 +2,8 @@ package lint
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -103,6 +105,29 @@ func TestGenderBias(t *testing.T) {
 	}
 }
 
+func TestLintStringContext(t *testing.T) {
+	linter, err := initLinter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = linter.LintStringContext(ctx, "This is is a test.", "test.md")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	// The canceled context doesn't outlive its call.
+	linted, err := linter.LintStringWithPath("This is is a test.", "test.md")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(linted[0].Alerts) != 1 {
+		t.Errorf("expected one alert, got %v", linted[0].Alerts)
+	}
+}
+
 func initLinter() (*Linter, error) {
 	cfg, err := core.NewConfig(&core.CLIFlags{})
 	if err != nil {
@@ -0,0 +1,165 @@
+package lint
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint/markup"
+)
+
+// lintMarkup lints f using one of our parsers for lightweight markup languages
+// (see `internal/lint/markup`).
+//
+// As with Markdown, any front matter is blanked out before parsing: static
+// site generators (e.g., Hugo) accept it in reStructuredText and AsciiDoc
+// files too, and it's only linted through a `FrontMatter` blueprint.
+func (l *Linter) lintMarkup(f *core.File, parse func(src string) []markup.Block) error {
+	src, comments, err := l.maskMarkdown(f)
+	if err != nil {
+		return err
+	}
+
+	w := newMdWalker(l, f, src, comments)
+	for _, b := range parse(string(src)) {
+		if err = w.markupBlock(b); err != nil {
+			return err
+		}
+	}
+	w.drain(len(src))
+
+	return l.lintSizedScopes(f)
+}
+
+// markupBlock lints a block emitted by one of our markup parsers.
+func (w *mdWalker) markupBlock(b markup.Block) error {
+	if len(b.Pos) == 0 {
+		return nil
+	} else if b.Comment {
+		w.drain(b.Pos[0])
+		w.f.UpdateComments(string(b.Text))
+		return nil
+	}
+
+	for i, tag := range b.Tags {
+		if core.StringInSlice(tag, w.skipTags) {
+			if i == len(b.Tags)-1 {
+				w.f.Metrics[tag]++
+			}
+			return nil
+		}
+	}
+
+	scope := markupScope(b.Tags)
+	if b.Raw {
+		t := &mdText{}
+		if err := w.rawHTML(b.Pos[0], b.Pos[len(b.Pos)-1]+1, t, true); err != nil {
+			return err
+		}
+		return w.lint(t, scope)
+	}
+
+	last := b.Tags[len(b.Tags)-1]
+	if last == "pre" {
+		// Literal blocks are never prose, even if "pre" isn't skipped.
+		return nil
+	}
+
+	t, err := w.markupText(b)
+	if err != nil {
+		return err
+	}
+
+	if last == "img" {
+		if !core.StringInSlice("alt", w.l.Manager.Config.SkippedScopes) {
+			w.pending = append(w.pending, t)
+		}
+		return w.lint(&mdText{}, "")
+	}
+	return w.lint(t, scope)
+}
+
+// markupText collects the text of a block, masking any inline elements that
+// shouldn't be linted.
+func (w *mdWalker) markupText(b markup.Block) (*mdText, error) {
+	t := &mdText{}
+	for i := 0; i < len(b.Text); {
+		if s, ok := special(b.Spans, i); ok {
+			switch {
+			case s.Raw:
+				stop := b.Pos[s.End-1] + 1
+				if err := w.rawHTML(b.Pos[s.Start], stop, t, false); err != nil {
+					return t, err
+				}
+			case !core.StringInSlice("alt", w.l.Manager.Config.SkippedScopes):
+				// Images are linted after the block that contains them.
+				alt := &mdText{}
+				alt.buf = append(alt.buf, b.Text[s.Start:s.End]...)
+				alt.pos = append(alt.pos, b.Pos[s.Start:s.End]...)
+				w.pending = append(w.pending, alt)
+			}
+			i = max(s.End, i+1)
+			continue
+		}
+
+		if b.Text[i] != 0 && !w.masked(b.Spans, i) {
+			t.buf = append(t.buf, b.Text[i])
+			t.pos = append(t.pos, b.Pos[i])
+			i++
+			continue
+		}
+
+		// Mask each rune with a single character, as we do for HTML.
+		r, size := utf8.DecodeRune(b.Text[i:])
+		if b.Text[i] == 0 {
+			// Ignored tokens are masked byte-by-byte in the source.
+			r, size = utf8.DecodeRuneInString(w.f.Content[b.Pos[i]:])
+		}
+		if r == '\n' {
+			t.add("\n", b.Pos[i])
+		} else {
+			t.add("*", b.Pos[i])
+		}
+		i += min(size, len(b.Text)-i)
+	}
+	return t, nil
+}
+
+// special returns the passthrough HTML or image that starts at the i-th byte
+// of a block, if any.
+func special(spans []markup.Span, i int) (markup.Span, bool) {
+	for _, s := range spans {
+		if s.Start == i && (s.Raw || s.Tag == "img") {
+			return s, true
+		}
+	}
+	return markup.Span{}, false
+}
+
+// masked reports whether the i-th byte of a block is part of an inline
+// element that shouldn't be linted.
+func (w *mdWalker) masked(spans []markup.Span, i int) bool {
+	for _, s := range spans {
+		if i >= s.Start && i < s.End && (s.Literal || w.ignores(s.Tag)) {
+			return true
+		}
+	}
+	return false
+}
+
+// markupScope returns the scope assigned by the outermost scoped tag, if any.
+//
+// Unlike in HTML, an `<aside>` is always an admonition (e.g., a note or a
+// warning).
+func markupScope(tags []string) string {
+	for _, tag := range tags {
+		if tag == "aside" {
+			return "admonition"
+		} else if scope, ok := tagToScope[tag]; ok {
+			return strings.TrimPrefix(scope, "text.")
+		} else if heading.MatchString(tag) {
+			return "heading." + tag
+		}
+	}
+	return ""
+}
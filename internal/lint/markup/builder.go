@@ -0,0 +1,99 @@
+package markup
+
+// A Text is a run of text, along with the source offset of each of its bytes
+// and the inline elements found in it.
+type Text struct {
+	Buf   []byte
+	Pos   []int
+	Spans []Span
+}
+
+// Add appends s, whose bytes were found at the given source offsets.
+func (t *Text) Add(s string, pos []int) {
+	t.Buf = append(t.Buf, s...)
+	t.Pos = append(t.Pos, pos[:len(s)]...)
+}
+
+// AddAt appends s, which doesn't exist in the source (e.g., the value of an
+// attribute reference), at the source offset at.
+func (t *Text) AddAt(s string, at int) {
+	for i := 0; i < len(s); i++ {
+		t.Buf = append(t.Buf, s[i])
+		t.Pos = append(t.Pos, at)
+	}
+}
+
+// Mark records everything added since Buf[start] as an inline element.
+func (t *Text) Mark(span Span, start int) {
+	span.Start, span.End = start, len(t.Buf)
+	t.Spans = append(t.Spans, span)
+}
+
+// A Builder collects the blocks of a document.
+type Builder struct {
+	Blocks []Block
+	// tags holds the tags that enclose the current block.
+	tags []string
+}
+
+// Open opens a tag that encloses the following blocks.
+func (b *Builder) Open(tag string) {
+	b.tags = append(b.tags, tag)
+}
+
+// Close closes the most recently opened tag.
+func (b *Builder) Close() {
+	b.tags = b.tags[:len(b.tags)-1]
+}
+
+// Emit emits t as a block with the given tag, unless it's empty.
+func (b *Builder) Emit(tag string, t *Text) {
+	if len(t.Buf) > 0 {
+		b.Blocks = append(b.Blocks, Block{
+			Tags: b.path(tag), Text: t.Buf, Pos: t.Pos, Spans: t.Spans})
+	}
+}
+
+// Pre emits text that shouldn't be linted.
+func (b *Builder) Pre(lines []Line) {
+	s, pos := Join(lines)
+	b.Blocks = append(b.Blocks, Block{Tags: b.path("pre"), Text: []byte(s), Pos: pos})
+}
+
+// Raw emits HTML that's passed through as-is, whose own tags are added to
+// those that enclose it.
+func (b *Builder) Raw(lines []Line) {
+	s, pos := Join(lines)
+	if s != "" {
+		tags := append([]string{}, b.tags...)
+		b.Blocks = append(b.Blocks, Block{Tags: tags, Text: []byte(s), Pos: pos, Raw: true})
+	}
+}
+
+// Alt emits an image's alternative text, which was found at the given source
+// offsets.
+func (b *Builder) Alt(alt string, pos []int) {
+	if alt != "" {
+		b.Blocks = append(b.Blocks, Block{Tags: b.path("img"), Text: []byte(alt), Pos: pos})
+	}
+}
+
+// Comment emits the content of a comment found at the source offset at.
+func (b *Builder) Comment(text string, at int) {
+	if text == "" {
+		return
+	}
+
+	pos := make([]int, len(text))
+	for i := range pos {
+		pos[i] = at
+	}
+
+	b.Blocks = append(b.Blocks, Block{
+		Tags: b.path("!--"), Text: []byte(text), Pos: pos, Comment: true})
+}
+
+// path returns the tags that enclose a new block with the given tag.
+func (b *Builder) path(tag string) []string {
+	return append(append([]string{}, b.tags...), tag)
+}
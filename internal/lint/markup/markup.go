@@ -0,0 +1,238 @@
+// Package markup contains the building blocks shared by our parsers for
+// lightweight markup languages (i.e., reStructuredText and AsciiDoc).
+//
+// Rather than rendering HTML, the parsers emit blocks: each one records the
+// HTML tags that it would be rendered as, which is what the linter's scopes
+// are based on, along with the source offset of every byte of its text. This
+// allows every alert to be assigned its exact location without having to
+// search for its match after the fact.
+package markup
+
+import (
+	"encoding/csv"
+	"strings"
+	"unicode"
+)
+
+// A Block is a run of text that's linted as a unit, such as a paragraph, a
+// heading, or a table cell.
+type Block struct {
+	// Tags are the HTML tags that enclose the block, from the outermost in
+	// (e.g., `blockquote`, `ul`, `li`, `p`).
+	//
+	// Content that shouldn't be linted (e.g., a code block) is tagged as
+	// `pre`, an image's alternative text is tagged as `img`, and admonitions
+	// (e.g., notes and warnings) are placed inside an `aside`.
+	Tags []string
+
+	// Text is the block's text, while Pos holds the source offset of each of
+	// its bytes.
+	Text []byte
+	Pos  []int
+
+	// Spans are the inline elements (e.g., `code`) found in Text.
+	Spans []Span
+
+	// Comment indicates that Text is the content of a comment, which may be
+	// a comment control (e.g., `vale off`).
+	Comment bool
+	// Raw indicates that Text is HTML that's passed through as-is.
+	Raw bool
+}
+
+// A Span is an inline element that covers Text[Start:End].
+type Span struct {
+	Tag        string
+	Start, End int
+
+	// Literal indicates that the span is never prose (e.g., a bare URL).
+	Literal bool
+	// Raw indicates that the span is HTML that's passed through as-is.
+	Raw bool
+}
+
+// A Line is a line of the source, along with the offset of its first byte.
+type Line struct {
+	Text string
+	Off  int
+}
+
+// From returns the line starting at its i-th byte.
+func (l Line) From(i int) Line {
+	return Line{Text: l.Text[i:], Off: l.Off + i}
+}
+
+// TrimRight removes any trailing spaces, tabs, and carriage returns.
+func (l Line) TrimRight() Line {
+	return Line{Text: strings.TrimRight(l.Text, " \t\r"), Off: l.Off}
+}
+
+// Trim removes any leading and trailing whitespace.
+func (l Line) Trim() Line {
+	text := strings.TrimLeftFunc(l.Text, unicode.IsSpace)
+	return Line{
+		Text: strings.TrimRightFunc(text, unicode.IsSpace),
+		Off:  l.Off + len(l.Text) - len(text),
+	}
+}
+
+// Split splits src into its lines.
+func Split(src string) []Line {
+	lines := []Line{}
+
+	off := 0
+	for {
+		idx := strings.IndexByte(src[off:], '\n')
+		if idx < 0 {
+			return append(lines, Line{Text: src[off:], Off: off})
+		}
+		lines = append(lines, Line{Text: src[off : off+idx], Off: off})
+		off += idx + 1
+	}
+}
+
+// Join joins the given lines with newlines, returning the source offset of
+// each byte of the result.
+func Join(lines []Line) (string, []int) {
+	var sb strings.Builder
+
+	pos := []int{}
+	for i, l := range lines {
+		if i > 0 {
+			sb.WriteByte('\n')
+			pos = append(pos, lines[i-1].Off+len(lines[i-1].Text))
+		}
+		sb.WriteString(l.Text)
+		for k := range len(l.Text) {
+			pos = append(pos, l.Off+k)
+		}
+	}
+
+	return sb.String(), pos
+}
+
+// Lines splits s, whose bytes were found at the given source offsets, into
+// lines.
+func Lines(s string, pos []int) []Line {
+	lines := []Line{}
+
+	start := 0
+	for _, part := range strings.Split(s, "\n") {
+		l := Line{Text: part}
+		if start < len(pos) {
+			l.Off = pos[start]
+		} else if len(pos) > 0 {
+			l.Off = pos[len(pos)-1]
+		}
+		lines = append(lines, l)
+		start += len(part) + 1
+	}
+
+	return lines
+}
+
+// Indented collects the lines starting at `lines[i]` that are either blank or
+// indented by at least `minIndent`. Trailing blank lines aren't included.
+func Indented(lines []Line, i, minIndent int) ([]Line, int) {
+	end := i
+	for j := i; j < len(lines); j++ {
+		if IsBlank(lines[j].Text) {
+			continue
+		} else if IndentOf(lines[j].Text) < minIndent {
+			break
+		}
+		end = j + 1
+	}
+	return lines[i:end], end
+}
+
+// Dedent removes the common leading whitespace from `lines`.
+func Dedent(lines []Line) []Line {
+	common := -1
+	for _, l := range lines {
+		if !IsBlank(l.Text) {
+			if n := IndentOf(l.Text); common < 0 || n < common {
+				common = n
+			}
+		}
+	}
+
+	out := make([]Line, len(lines))
+	for i, l := range lines {
+		if IsBlank(l.Text) {
+			out[i] = Line{Off: l.Off}
+		} else {
+			out[i] = l.From(common).TrimRight()
+		}
+	}
+
+	return out
+}
+
+// IndentOf returns the number of leading spaces and tabs in s.
+func IndentOf(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " \t"))
+}
+
+// IsBlank reports whether s consists solely of whitespace.
+func IsBlank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+// RuneOffsets returns the byte offset of each rune in s, followed by len(s).
+func RuneOffsets(s string) []int {
+	offsets := make([]int, 0, len(s)+1)
+	for i := range s {
+		offsets = append(offsets, i)
+	}
+	return append(offsets, len(s))
+}
+
+// A Field is a (trimmed) field of a CSV record, along with the source offset
+// of each of its bytes.
+type Field struct {
+	Text string
+	Pos  []int
+}
+
+// ReadCSV reads the records of the CSV text s, whose bytes were found at the
+// given source offsets.
+func ReadCSV(s string, pos []int, delim rune) [][]Field {
+	r := csv.NewReader(strings.NewReader(s))
+	r.Comma = delim
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	// On error, we lint whatever we were able to read.
+	records, _ := r.ReadAll()
+
+	// The reader doesn't tell us where each field is, so we search for it
+	// after the end of the previous one. Fields that we can't find (e.g.,
+	// those with escaped quotes) are assigned the position of the cursor.
+	out := [][]Field{}
+
+	cursor := 0
+	for _, record := range records {
+		fields := []Field{}
+		for _, text := range record {
+			text = strings.TrimSpace(text)
+
+			field := Field{Text: text}
+			if idx := strings.Index(s[cursor:], text); idx >= 0 {
+				start := cursor + idx
+				field.Pos = pos[start : start+len(text)]
+				cursor = start + len(text)
+			} else {
+				field.Pos = make([]int, len(text))
+				for k := range field.Pos {
+					field.Pos[k] = pos[min(cursor, len(pos)-1)]
+				}
+			}
+			fields = append(fields, field)
+		}
+		out = append(out, fields)
+	}
+
+	return out
+}
@@ -1,15 +1,19 @@
 package lint
 
 import (
-	"bytes"
+	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/errata-ai/regexp2"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
 	grh "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
 
 	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/glob"
 	"github.com/errata-ai/vale/v3/internal/nlp"
 )
 
@@ -33,20 +37,167 @@ var reLinkDef = regexp.MustCompile(`\[(?:[^]\n]+)\]:`)
 
 var reNumericList = regexp.MustCompile(`(?m)^\d+\.`)
 
-func (l Linter) lintMarkdown(f *core.File) error {
-	var buf bytes.Buffer
+func (l *Linter) lintMarkdown(f *core.File) error {
+	if err := l.walkMarkdown(f); err != nil {
+		return err
+	}
+
+	f.Content = prepMarkdown(f.Content)
+	return l.lintSizedScopes(f)
+}
 
-	s, err := l.Transform(f)
+// walkMarkdown lints each block of f's content, without running any rules
+// that require the whole document (`scope: summary`).
+func (l *Linter) walkMarkdown(f *core.File) error {
+	src, comments, err := l.maskMarkdown(f)
 	if err != nil {
 		return err
 	}
 
-	if err = goldMd.Convert([]byte(s), &buf); err != nil {
-		return core.NewE100(f.Path, err)
+	doc := goldMd.Parser().Parse(text.NewReader(src))
+	return newMdWalker(l, f, src, comments).walk(doc)
+}
+
+// maskMarkdown returns a copy of f's content in which its front matter and
+// any configured ignore patterns have been masked, along with any comments
+// written using custom delimiters.
+//
+// Unlike `Transform`, masking never changes the length of the content: this
+// allows us to map every node in the parsed AST back to its exact location in
+// the original file.
+func (l *Linter) maskMarkdown(f *core.File) ([]byte, []mdComment, error) {
+	var comments []mdComment
+
+	cfg := l.Manager.Config
+	exts := extensionConfig{Normed: f.NormedExt, Real: f.RealExt}
+
+	src := []byte(f.Content)
+	runes := runeOffsets(f.Content)
+
+	// Ignored blocks (including front matter) are blanked out entirely, which
+	// leaves the parser with nothing but empty lines.
+	if loc := reFrontMatter.FindStringIndex(f.Content); loc != nil {
+		maskBytes(src, loc[0], loc[1], ' ')
 	}
 
-	f.Content = prepMarkdown(f.Content)
-	return l.lintHTMLTokens(f, buf.Bytes(), 0)
+	blocks, err := ignoredRanges(cfg, exts, cfg.BlockIgnores, f.Content, runes)
+	if err != nil {
+		return src, comments, err
+	}
+	for _, r := range blocks {
+		maskBytes(src, r[0], r[1], ' ')
+	}
+
+	// Ignored tokens are replaced with NUL bytes, which the parser treats as
+	// regular text and which we later lint as masked code.
+	tokens, err := ignoredRanges(cfg, exts, cfg.TokenIgnores, f.Content, runes)
+	if err != nil {
+		return src, comments, err
+	}
+	for _, r := range tokens {
+		maskBytes(src, r[0], r[1], 0)
+	}
+
+	for syntax, delims := range cfg.CommentDelimiters {
+		sec, errc := glob.Compile(syntax)
+		if errc != nil {
+			return src, comments, errc
+		} else if !sec.Match(exts.Normed) && !sec.Match(exts.Real) {
+			continue
+		}
+
+		// This field was not assigned, so do nothing.
+		if delims[0] == "" && delims[1] == "" {
+			break
+		}
+		// Return an error if only one delimiter is configured
+		if delims[0] == "" || delims[1] == "" {
+			return src, comments, fmt.Errorf("CommentDelimiters must be empty or have two values")
+		}
+
+		start := 0
+		for {
+			open := strings.Index(f.Content[start:], delims[0])
+			if open < 0 {
+				break
+			}
+			open += start
+
+			body := open + len(delims[0])
+			end := strings.Index(f.Content[body:], delims[1])
+			if end < 0 {
+				break
+			}
+			end += body
+
+			comments = append(comments, mdComment{
+				offset: open,
+				text:   strings.TrimSpace(f.Content[body:end]),
+			})
+
+			start = end + len(delims[1])
+			maskBytes(src, open, start, ' ')
+		}
+	}
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].offset < comments[j].offset
+	})
+
+	return src, comments, nil
+}
+
+// ignoredRanges returns the byte ranges of content matched by any of the
+// patterns that apply to the given extensions.
+func ignoredRanges(c *core.Config, exts extensionConfig, patterns map[string][]string, content string, runes []int) ([][2]int, error) {
+	var ranges [][2]int
+
+	for syntax, regexes := range patterns {
+		sec, err := glob.Compile(syntax)
+		if err != nil {
+			return ranges, err
+		} else if !sec.Match(exts.Normed) && !sec.Match(exts.Real) {
+			continue
+		}
+
+		for _, r := range regexes {
+			pat, errc := regexp2.CompileStd(r)
+			if errc != nil {
+				return ranges, core.NewE201FromTarget(errc.Error(), r, c.Flags.Path)
+			}
+
+			m, errc := pat.FindStringMatch(content)
+			for m != nil && errc == nil {
+				// NOTE: `regexp2` reports its positions in runes.
+				ranges = append(ranges, [2]int{runes[m.Index], runes[m.Index+m.Length]})
+				m, errc = pat.FindNextMatch(m)
+			}
+			if errc != nil {
+				return ranges, core.NewE201FromTarget(errc.Error(), r, c.Flags.Path)
+			}
+		}
+	}
+
+	return ranges, nil
+}
+
+// runeOffsets returns the byte offset of each rune in s, followed by len(s).
+func runeOffsets(s string) []int {
+	offsets := make([]int, 0, len(s)+1)
+	for i := range s {
+		offsets = append(offsets, i)
+	}
+	return append(offsets, len(s))
+}
+
+// maskBytes replaces every byte in src[start:end], except newlines, with
+// char.
+func maskBytes(src []byte, start, end int, char byte) {
+	for i := start; i < end; i++ {
+		if src[i] != '\n' {
+			src[i] = char
+		}
+	}
 }
 
 func prepMarkdown(content string) string {
@@ -0,0 +1,668 @@
+package lint
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+	"golang.org/x/net/html"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/nlp"
+)
+
+var reEntity = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+
+// An mdComment is a comment written using custom delimiters (see
+// `CommentDelimiters`).
+type mdComment struct {
+	offset int
+	text   string
+}
+
+// An mdText is a run of text collected from the AST, along with the source
+// offset of each of its bytes.
+type mdText struct {
+	buf []byte
+	pos []int
+}
+
+// add appends s, which was found at the source offset at.
+func (t *mdText) add(s string, at int) {
+	for i := 0; i < len(s); i++ {
+		t.buf = append(t.buf, s[i])
+		t.pos = append(t.pos, at)
+	}
+}
+
+// last returns the source offset of the most recently added byte.
+func (t *mdText) last() int {
+	if len(t.pos) == 0 {
+		return 0
+	}
+	return t.pos[len(t.pos)-1]
+}
+
+// An mdWalker walks a Markdown AST, linting each of its blocks.
+//
+// Since the AST's segments point into the original source, every alert can be
+// assigned its exact location: there's no need to search for its match in the
+// file's content after the fact.
+type mdWalker struct {
+	l *Linter
+	f *core.File
+
+	// src is the masked source (see `maskMarkdown`) that was parsed, while
+	// starts holds the offset at which each of its lines begins.
+	src    []byte
+	starts []int
+
+	comments []mdComment
+
+	skipTags    []string
+	skipClasses []string
+	ignored     []string
+
+	// skipping is the raw HTML tag (e.g., `<script>`) whose content we're
+	// currently skipping and depth is the number of open instances of it.
+	skipping string
+	depth    int
+
+	// blocks holds the open block-level HTML tags, while masks holds the open
+	// inline HTML tags whose content should be masked.
+	blocks []string
+	masks  []string
+
+	// pending holds the alternative text of any images found in the current
+	// block.
+	pending []*mdText
+}
+
+func newMdWalker(l *Linter, f *core.File, src []byte, comments []mdComment) *mdWalker {
+	w := &mdWalker{
+		l: l, f: f, src: src, comments: comments,
+		skipTags:    skipTags,
+		skipClasses: skipClasses,
+		ignored:     []string{"tt", "code", "kbd"},
+	}
+
+	cfg := l.Manager.Config
+	if len(cfg.SkippedScopes) > 0 {
+		w.skipTags = cfg.SkippedScopes
+	}
+	if len(cfg.IgnoredClasses) > 0 {
+		w.skipClasses = append(w.skipClasses, cfg.IgnoredClasses...)
+	}
+	if len(cfg.IgnoredScopes) > 0 {
+		w.ignored = cfg.IgnoredScopes
+	}
+
+	w.starts = []int{0}
+	for i, b := range src {
+		if b == '\n' {
+			w.starts = append(w.starts, i+1)
+		}
+	}
+
+	return w
+}
+
+func (w *mdWalker) walk(doc ast.Node) error {
+	if err := w.children(doc, ""); err != nil {
+		return err
+	}
+	w.drain(len(w.src))
+	return nil
+}
+
+func (w *mdWalker) children(n ast.Node, scope string) error {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if err := w.block(c, scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// block lints a block-level node, where scope is the scope assigned by its
+// nearest scoped ancestor (e.g., a list item).
+func (w *mdWalker) block(n ast.Node, scope string) error {
+	if tag := blockTag(n); tag != "" && core.StringInSlice(tag, w.skipTags) {
+		w.f.Metrics[tag]++
+		return nil
+	}
+
+	switch n := n.(type) {
+	case *ast.Heading:
+		return w.lint(w.inlines(n), "heading.h"+strconv.Itoa(n.Level))
+	case *ast.Paragraph, *ast.TextBlock:
+		return w.lint(w.inlines(n), scope)
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		// Code blocks are never prose, even if "pre" isn't skipped.
+		return nil
+	case *ast.HTMLBlock:
+		return w.rawBlock(n)
+	case *ast.Blockquote:
+		return w.children(n, inherit(scope, "blockquote"))
+	case *ast.ListItem, *east.Footnote:
+		return w.children(n, inherit(scope, "list"))
+	case *east.TableHeader:
+		return w.cells(n, "table.header")
+	case *east.TableRow:
+		return w.cells(n, "table.cell")
+	}
+	return w.children(n, scope)
+}
+
+// blockTag returns the HTML tag that n would be rendered as, which is what
+// `SkippedScopes` refers to.
+func blockTag(n ast.Node) string {
+	switch n := n.(type) {
+	case *ast.Heading:
+		return "h" + strconv.Itoa(n.Level)
+	case *ast.Paragraph:
+		return "p"
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		return "pre"
+	case *ast.Blockquote:
+		return "blockquote"
+	case *ast.List:
+		if n.IsOrdered() {
+			return "ol"
+		}
+		return "ul"
+	case *ast.ListItem:
+		return "li"
+	case *east.Table:
+		return "table"
+	}
+	return ""
+}
+
+func (w *mdWalker) cells(row ast.Node, scope string) error {
+	for c := row.FirstChild(); c != nil; c = c.NextSibling() {
+		if err := w.lint(w.inlines(c), scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inherit returns the scope of a nested block: the outermost scope wins, as
+// it did when we linted the rendered HTML.
+func inherit(outer, inner string) string {
+	if outer != "" {
+		return outer
+	}
+	return inner
+}
+
+// inlines collects the text of n's inline children.
+func (w *mdWalker) inlines(n ast.Node) *mdText {
+	t := &mdText{}
+	w.collect(n, t, false)
+	return t
+}
+
+func (w *mdWalker) collect(n ast.Node, t *mdText, masked bool) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c := c.(type) {
+		case *ast.Text:
+			w.addSource(t, c.Segment.Start, c.Segment.Stop, masked || len(w.masks) > 0)
+			if c.SoftLineBreak() || c.HardLineBreak() {
+				t.add("\n", c.Segment.Stop)
+			}
+		case *ast.String:
+			t.add(string(c.Value), t.last())
+		case *ast.CodeSpan:
+			w.collect(c, t, masked || w.ignores("code"))
+		case *ast.Emphasis:
+			tag := "em"
+			if c.Level == 2 {
+				tag = "strong"
+			}
+			w.collect(c, t, masked || w.ignores(tag))
+		case *east.Strikethrough:
+			w.collect(c, t, masked || w.ignores("del"))
+		case *ast.Link:
+			// Links whose text is just their URL aren't prose.
+			label := string(c.Text(w.src)) //nolint:staticcheck
+			w.collect(c, t, masked || w.ignores("a") || label == string(c.Destination))
+		case *ast.AutoLink:
+			url := c.Label(w.src)
+			if idx := bytes.Index(w.src[t.last():], url); idx >= 0 {
+				start := t.last() + idx
+				w.addSource(t, start, start+len(url), true)
+			}
+		case *ast.Image:
+			w.alt(c)
+		case *ast.RawHTML:
+			for i := 0; i < c.Segments.Len(); i++ {
+				seg := c.Segments.At(i)
+				w.rawHTML(seg.Start, seg.Stop, t, false)
+			}
+		case *east.FootnoteLink, *east.FootnoteBacklink, *east.TaskCheckBox:
+			continue
+		default:
+			w.collect(c, t, masked)
+		}
+	}
+}
+
+// addSource appends src[start:stop] to t, resolving any backslash escapes and
+// character references.
+func (w *mdWalker) addSource(t *mdText, start, stop int, masked bool) {
+	content := w.f.Content
+
+	for i := start; i < stop; {
+		b := w.src[i]
+		switch {
+		case masked || b == 0:
+			// Mask each rune with a single character, as we do for HTML.
+			r, size := utf8.DecodeRuneInString(content[i:])
+			if r == '\n' {
+				t.add("\n", i)
+			} else {
+				t.add("*", i)
+			}
+			i += size
+		case b == '\\' && i+1 < stop && isASCIIPunct(w.src[i+1]):
+			t.add(string(w.src[i+1]), i+1)
+			i += 2
+		case b == '&':
+			if m := reEntity.Find(w.src[i:stop]); m != nil {
+				t.add(html.UnescapeString(string(m)), i)
+				i += len(m)
+			} else {
+				t.add("&", i)
+				i++
+			}
+		default:
+			t.buf = append(t.buf, b)
+			t.pos = append(t.pos, i)
+			i++
+		}
+	}
+}
+
+func isASCIIPunct(b byte) bool {
+	return b < utf8.RuneSelf && strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", b) >= 0
+}
+
+func (w *mdWalker) ignores(tag string) bool {
+	return core.StringInSlice(tag, w.ignored)
+}
+
+// alt lints an image's alternative text.
+func (w *mdWalker) alt(img *ast.Image) {
+	if core.StringInSlice("alt", w.l.Manager.Config.SkippedScopes) || w.skipping != "" {
+		return
+	}
+	t := &mdText{}
+	w.collect(img, t, false)
+	w.pending = append(w.pending, t)
+}
+
+// rawBlock processes an HTML block.
+func (w *mdWalker) rawBlock(n *ast.HTMLBlock) error {
+	lines := n.Lines()
+	if lines.Len() == 0 {
+		return nil
+	}
+
+	start := lines.At(0).Start
+	stop := lines.At(lines.Len() - 1).Stop
+	if n.HasClosure() {
+		stop = n.ClosureLine.Stop
+	}
+
+	t := &mdText{}
+	if err := w.rawHTML(start, stop, t, true); err != nil {
+		return err
+	}
+	return w.lint(t, w.htmlScope())
+}
+
+// rawHTML processes the raw HTML found at src[start:stop], adding its text to
+// t.
+//
+// In block-level HTML, t is linted every time a block-level tag opens or
+// closes.
+func (w *mdWalker) rawHTML(start, stop int, t *mdText, block bool) error {
+	z := html.NewTokenizer(bytes.NewReader(w.src[start:stop]))
+
+	offset := start
+	for {
+		tokt := z.Next()
+		if tokt == html.ErrorToken {
+			return nil
+		}
+
+		at := offset
+		raw := z.Raw()
+		offset += len(raw)
+
+		tok := z.Token()
+		name := tok.Data
+
+		switch tokt {
+		case html.CommentToken:
+			w.drain(at)
+			w.f.UpdateComments(strings.TrimSpace(tok.Data))
+		case html.TextToken:
+			if w.skipping == "" {
+				w.addSource(t, at, at+len(raw), len(w.masks) > 0)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if w.skipping != "" {
+				if name == w.skipping && tokt == html.StartTagToken {
+					w.depth++
+				}
+				continue
+			}
+
+			inline := core.StringInSlice(name, inlineTags)
+			skipClass := checkClasses(getAttribute(tok, "class"), w.skipClasses)
+
+			if core.StringInSlice(name, w.skipTags) || (skipClass && !inline) {
+				if err := w.flush(t, block); err != nil {
+					return err
+				}
+				w.f.Metrics[name]++
+				if tokt == html.StartTagToken {
+					w.skipping, w.depth = name, 1
+				}
+			} else if name == "img" {
+				w.rawAlt(tok, at, raw)
+			} else if inline {
+				if tokt == html.StartTagToken && name != "br" && (skipClass || w.ignores(name)) {
+					w.masks = append(w.masks, name)
+				}
+			} else {
+				if err := w.flush(t, block); err != nil {
+					return err
+				}
+				if tokt == html.StartTagToken {
+					w.blocks = append(w.blocks, name)
+				}
+			}
+		case html.EndTagToken:
+			if w.skipping != "" {
+				if name == w.skipping {
+					w.depth--
+					if w.depth == 0 {
+						w.skipping = ""
+					}
+				}
+				continue
+			}
+
+			if core.StringInSlice(name, inlineTags) {
+				w.masks = pop(w.masks, name)
+			} else {
+				if err := w.flush(t, block); err != nil {
+					return err
+				}
+				w.blocks = pop(w.blocks, name)
+			}
+		}
+	}
+}
+
+// rawAlt lints the `alt` attribute of a raw `<img>` tag.
+func (w *mdWalker) rawAlt(tok html.Token, at int, raw []byte) {
+	if core.StringInSlice("alt", w.l.Manager.Config.SkippedScopes) {
+		return
+	}
+
+	for _, a := range tok.Attr {
+		if a.Key != "alt" || a.Val == "" {
+			continue
+		}
+
+		t := &mdText{}
+		if idx := bytes.Index(raw, []byte(a.Val)); idx >= 0 {
+			w.addSource(t, at+idx, at+idx+len(a.Val), false)
+		} else {
+			t.add(a.Val, at)
+		}
+		w.pending = append(w.pending, t)
+	}
+}
+
+// pop removes the most recent instance of tag from stack, along with any
+// tags opened after it.
+func pop(stack []string, tag string) []string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == tag {
+			return stack[:i]
+		}
+	}
+	return stack
+}
+
+// flush lints, and then resets, the text collected from block-level HTML.
+func (w *mdWalker) flush(t *mdText, block bool) error {
+	if !block {
+		return nil
+	}
+	err := w.lint(&mdText{buf: t.buf, pos: t.pos}, w.htmlScope())
+	t.buf, t.pos = nil, nil
+	return err
+}
+
+// htmlScope returns the scope assigned by the outermost open block-level HTML
+// tag, if any.
+func (w *mdWalker) htmlScope() string {
+	for _, tag := range w.blocks {
+		if scope, ok := tagToScope[tag]; ok {
+			return strings.TrimPrefix(scope, "text.")
+		} else if heading.MatchString(tag) {
+			return "heading." + tag
+		}
+	}
+	return ""
+}
+
+// drain applies every custom-delimited comment found before offset.
+func (w *mdWalker) drain(offset int) {
+	for len(w.comments) > 0 && w.comments[0].offset < offset {
+		w.f.UpdateComments(w.comments[0].text)
+		w.comments = w.comments[1:]
+	}
+}
+
+// lint lints the text t, where an empty scope denotes a paragraph.
+func (w *mdWalker) lint(t *mdText, scope string) error {
+	trimmed := trimText(t)
+	if len(trimmed.buf) > 0 {
+		w.drain(trimmed.last() + 1)
+	}
+
+	var err error
+	if len(trimmed.buf) > 0 && w.skipping == "" {
+		err = w.lintText(trimmed, inherit(scope, w.htmlScope()))
+	}
+
+	// Images are linted after the block that contains them.
+	for len(w.pending) > 0 && err == nil {
+		alt := trimText(w.pending[0])
+		w.pending = w.pending[1:]
+		if len(alt.buf) > 0 {
+			b := nlp.NewLinedBlock("", string(alt.buf), "text.attr.alt", w.line(alt.pos[0])-1, nil)
+			err = w.run(b, alt, 0)
+		}
+	}
+
+	return err
+}
+
+func (w *mdWalker) lintText(t *mdText, scope string) error {
+	f := w.f
+	txt := string(t.buf)
+
+	if scope == "" {
+		f.Summary.WriteString(txt + "\n\n")
+
+		b := nlp.NewLinedBlock("", txt, "text"+w.l.metaScope+f.RealExt, w.line(t.pos[0])-1, nil)
+		return w.prose(b, t)
+	}
+
+	if scope == "blockquote" || scope == "list" || scope == "admonition" {
+		f.Summary.WriteString(txt + "\n\n")
+	}
+	f.Metrics[scope]++
+
+	b := nlp.NewLinedBlock("", txt, "text."+scope+w.l.metaScope+f.RealExt, w.line(t.pos[0])-1, nil)
+	if strings.HasPrefix(scope, "heading.") {
+		line, col := w.position(t.pos[0])
+		f.Headings = append(f.Headings, core.Heading{
+			Level:  int(scope[len(scope)-1] - '0'),
+			Text:   txt,
+			Line:   line,
+			Column: col,
+		})
+	} else if scope == "admonition" {
+		return w.prose(b, t)
+	}
+
+	return w.run(b, t, 0)
+}
+
+// prose lints a block of prose, along with any of its paragraphs and
+// sentences.
+func (w *mdWalker) prose(blk nlp.Block, t *mdText) error {
+	blks, err := w.f.NLP.Compute(&blk)
+	if err != nil {
+		return core.NewE100("NLP.Compute", err)
+	}
+
+	// NOTE: Paragraphs and sentences are substrings of the block's text, so we
+	// only need to know where each one starts.
+	cursors := map[string]int{}
+	for _, b := range blks {
+		kind, _, _ := strings.Cut(b.Scope, ".")
+
+		base := 0
+		if b.Scope != blk.Scope {
+			if idx := strings.Index(blk.Text[cursors[kind]:], b.Text); idx >= 0 {
+				base = cursors[kind] + idx
+				cursors[kind] = base + len(b.Text)
+			}
+		}
+
+		if err = w.run(b, t, base); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// run lints blk, whose text starts at t.buf[base].
+func (w *mdWalker) run(blk nlp.Block, t *mdText, base int) error {
+	return w.l.runBlock(w.f, blk, func(a core.Alert) {
+		if a.Line <= 0 {
+			start, end, ok := byteSpan(blk.Text, a)
+			if !ok {
+				return
+			}
+			a.Line, a.Span = w.locate(t, base+start, base+end)
+		}
+		w.f.AddLocatedAlert(a)
+	})
+}
+
+// byteSpan returns the byte offsets of a's match within text.
+//
+// Rules report their spans in either bytes or runes (e.g., those backed by
+// `regexp2`), so we use the match itself to tell which one we were given.
+func byteSpan(text string, a core.Alert) (int, int, bool) {
+	start, end := a.Span[0], a.Span[1]
+	if start < 0 || end < start {
+		start, end = -1, -1
+	} else if a.Match == "" {
+		return start, end, start <= len(text)
+	}
+
+	if start >= 0 && end <= len(text) && text[start:end] == a.Match {
+		return start, end, true
+	}
+
+	runes := runeOffsets(text)
+	if start >= 0 && end < len(runes) && text[runes[start]:runes[end]] == a.Match {
+		return runes[start], runes[end], true
+	}
+
+	// The rule couldn't locate its own match.
+	if idx := strings.Index(text, a.Match); idx >= 0 && a.Match != "" {
+		return idx, idx + len(a.Match), true
+	}
+	return 0, 0, false
+}
+
+// locate converts the byte range t.buf[start:end] into a line and a
+// (1-based, inclusive) column span.
+func (w *mdWalker) locate(t *mdText, start, end int) (int, []int) {
+	n := len(t.buf)
+	if start < 0 || start >= n {
+		start = 0
+	}
+	if end > n {
+		end = n
+	}
+	if end <= start {
+		end = start + 1
+	}
+
+	_, size := utf8.DecodeLastRune(t.buf[start:end])
+
+	line, begin := w.position(t.pos[start])
+	last, finish := w.position(t.pos[end-size])
+	if last != line {
+		// Alerts can't span more than one line, so we stop at the end of the
+		// first.
+		stop := len(w.src)
+		if line < len(w.starts) {
+			stop = w.starts[line] - 1
+		}
+		finish = utf8.RuneCountInString(w.f.Content[w.starts[line-1]:stop])
+	}
+
+	return line, []int{begin, finish}
+}
+
+// position returns the (1-based) line and column of the given source offset.
+func (w *mdWalker) position(offset int) (int, int) {
+	line := w.line(offset)
+	start := w.starts[line-1]
+	return line, utf8.RuneCountInString(w.f.Content[start:offset]) + 1
+}
+
+// line returns the (1-based) line of the given source offset.
+func (w *mdWalker) line(offset int) int {
+	return sort.Search(len(w.starts), func(i int) bool {
+		return w.starts[i] > offset
+	})
+}
+
+// trimText returns t without any leading or trailing whitespace.
+func trimText(t *mdText) *mdText {
+	start, end := 0, len(t.buf)
+	for start < end && isSpace(t.buf[start]) {
+		start++
+	}
+	for end > start && isSpace(t.buf[end-1]) {
+		end--
+	}
+	return &mdText{buf: t.buf[start:end], pos: t.pos[start:end]}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
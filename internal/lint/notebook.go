@@ -0,0 +1,323 @@
+package lint
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint/code"
+)
+
+// kernelToExt maps the languages of common Jupyter kernels to their file
+// extensions, for notebooks that don't specify `language_info`.
+var kernelToExt = map[string]string{
+	"bash":       ".sh",
+	"c#":         ".cs",
+	"c++":        ".cpp",
+	"csharp":     ".cs",
+	"go":         ".go",
+	"java":       ".java",
+	"javascript": ".js",
+	"julia":      ".jl",
+	"kotlin":     ".kt",
+	"lua":        ".lua",
+	"python":     ".py",
+	"r":          ".r",
+	"ruby":       ".rb",
+	"rust":       ".rs",
+	"scala":      ".scala",
+	"sql":        ".sql",
+	"typescript": ".ts",
+}
+
+// A notebook is the subset of the Jupyter notebook format (nbformat 4) that
+// we lint.
+type notebook struct {
+	Cells []struct {
+		Type   string   `json:"cell_type"`
+		Source nbSource `json:"source"`
+	} `json:"cells"`
+	Metadata struct {
+		Kernel struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+		Language struct {
+			Name      string `json:"name"`
+			Extension string `json:"file_extension"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+}
+
+// An nbSource is a cell's source, which may be stored as either a single
+// string or a list of lines.
+type nbSource string
+
+func (s *nbSource) UnmarshalJSON(data []byte) error {
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err == nil {
+		*s = nbSource(strings.Join(lines, ""))
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+
+	*s = nbSource(text)
+	return nil
+}
+
+// ext returns the file extension of the notebook's kernel language.
+func (nb *notebook) ext() string {
+	if ext := nb.Metadata.Language.Extension; ext != "" {
+		return ext
+	}
+
+	lang := nb.Metadata.Language.Name
+	if lang == "" {
+		lang = nb.Metadata.Kernel.Language
+	}
+	return kernelToExt[strings.ToLower(lang)]
+}
+
+// lintNotebook lints a Jupyter notebook: Markdown cells are linted as
+// Markdown and code cells are linted as source code in the language of the
+// notebook's kernel.
+//
+// Each alert is located relative to its cell -- i.e., `Line` is a line within
+// the cell's source, while `Cell` is the cell's (1-based) index.
+func (l *Linter) lintNotebook(f *core.File) error {
+	var nb notebook
+
+	if err := json.Unmarshal([]byte(f.Content), &nb); err != nil {
+		return core.NewE100(f.Path, err)
+	}
+
+	ext := nb.ext()
+	lang, langErr := code.GetLanguageFromExt(ext)
+
+	wholeFile := f.Content
+
+	last := 0
+	for i, cell := range nb.Cells {
+		src := string(cell.Source)
+		if strings.TrimSpace(src) == "" {
+			continue
+		}
+		f.SetText(src)
+
+		var err error
+		switch cell.Type {
+		case "markdown":
+			l.SetMetaScope("")
+			f.SetNormedExt("md")
+			err = l.walkMarkdown(f)
+		case "code":
+			if ext == "" {
+				continue
+			}
+			f.NormedExt = core.GetNormedExt(ext)
+			if langErr != nil {
+				// No tree-sitter grammar available for this language.
+				err = l.lintCodeOld(f)
+			} else {
+				err = l.lintComments(f, lang)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		for j := last; j < len(f.Alerts); j++ {
+			f.Alerts[j].Cell = i + 1
+		}
+		last = len(f.Alerts)
+	}
+
+	l.SetMetaScope("")
+	f.SetNormedExt("ipynb")
+	f.SetText(wholeFile)
+
+	// Rules with `scope: summary` apply to the notebook as a whole.
+	return l.lintSizedScopes(f)
+}
+
+// LocateCells returns a copy of alerts in which those located relative to a
+// notebook cell (see `lintNotebook`) are instead located relative to the
+// notebook's JSON source, src.
+//
+// This is for formats that address lines of the file itself (e.g., GitHub
+// annotations), which have no notion of cells.
+func LocateCells(src string, alerts []core.Alert) []core.Alert {
+	var sources [][]int
+
+	located := make([]core.Alert, len(alerts))
+	for i, a := range alerts {
+		located[i] = a
+		if a.Cell < 1 {
+			continue
+		} else if sources == nil {
+			var err error
+			if sources, err = cellSources(src); err != nil {
+				return alerts
+			}
+		}
+
+		if a.Cell > len(sources) {
+			continue
+		}
+
+		line, start, ok := locateInCell(src, sources[a.Cell-1], a.Line, a.Span[0])
+		if !ok {
+			continue
+		}
+		_, end, ok := locateInCell(src, sources[a.Cell-1], a.Line, a.Span[1])
+		if !ok {
+			end = start + a.Span[1] - a.Span[0]
+		}
+
+		located[i].Cell = 0
+		located[i].Line = line
+		located[i].Span = []int{start, end}
+	}
+
+	return located
+}
+
+// cellSources returns the offsets, within the notebook src, of the JSON
+// strings that make up each cell's source.
+func cellSources(src string) ([][]int, error) {
+	var sources [][]int
+
+	dec := json.NewDecoder(strings.NewReader(src))
+	err := eachKey(dec, func(key string) error {
+		if key != "cells" {
+			return skipValue(dec)
+		}
+
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		for dec.More() {
+			var offsets []int
+			err := eachKey(dec, func(key string) error {
+				if key != "source" {
+					return skipValue(dec)
+				}
+
+				start := nextToken(src, dec.InputOffset())
+				tok, err := dec.Token()
+				if err != nil {
+					return err
+				} else if _, ok := tok.(string); ok {
+					offsets = append(offsets, start)
+					return nil
+				}
+
+				for dec.More() {
+					offsets = append(offsets, nextToken(src, dec.InputOffset()))
+					if _, err = dec.Token(); err != nil {
+						return err
+					}
+				}
+				_, err = dec.Token()
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			sources = append(sources, offsets)
+		}
+
+		_, err := dec.Token()
+		return err
+	})
+
+	return sources, err
+}
+
+// eachKey calls fn for each key of the JSON object read by dec, which must
+// consume the key's value.
+func eachKey(dec *json.Decoder, fn func(key string) error) error {
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		} else if err = fn(tok.(string)); err != nil {
+			return err
+		}
+	}
+
+	_, err := dec.Token()
+	return err
+}
+
+// skipValue consumes the next JSON value read by dec.
+func skipValue(dec *json.Decoder) error {
+	var v json.RawMessage
+	return dec.Decode(&v)
+}
+
+// nextToken returns the offset of the first token at or after offset.
+func nextToken(src string, offset int64) int {
+	i := int(offset)
+	for i < len(src) && strings.IndexByte(" \t\r\n,:", src[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
+// locateInCell returns the line and column, within src, of the given
+// (1-based) line and column of a cell whose source is made up of the JSON
+// strings at offsets.
+//
+// Since JSON strings can't contain literal newlines, each string is on a
+// single line of src; we decode them one character at a time to account for
+// escapes.
+func locateInCell(src string, offsets []int, line, col int) (int, int, bool) {
+	l, c := 1, 1
+	for _, offset := range offsets {
+		for i := offset + 1; i < len(src) && src[i] != '"'; {
+			if l == line && c == col {
+				start := strings.LastIndexByte(src[:i], '\n') + 1
+				return strings.Count(src[:i], "\n") + 1, utf8.RuneCountInString(src[start:i]) + 1, true
+			}
+
+			size := 0
+			switch {
+			case src[i] == '\\' && i+1 < len(src) && src[i+1] == 'u':
+				size = 6
+				if strings.HasPrefix(src[i+size:], `\u`) && isHighSurrogate(src[i+2:i+6]) {
+					size = 12
+				}
+			case src[i] == '\\':
+				size = 2
+			default:
+				_, size = utf8.DecodeRuneInString(src[i:])
+			}
+
+			if src[i:min(i+2, len(src))] == `\n` {
+				l, c = l+1, 1
+			} else {
+				c++
+			}
+			i += size
+		}
+	}
+
+	return 0, 0, false
+}
+
+// isHighSurrogate reports whether the four hex digits in s encode the first
+// half of a UTF-16 surrogate pair.
+func isHighSurrogate(s string) bool {
+	r, err := strconv.ParseUint(s, 16, 16)
+	return err == nil && r >= 0xD800 && r < 0xDC00
+}
@@ -0,0 +1,142 @@
+package lint
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+// A Profile records where a Linter spends its time: running each rule and
+// parsing each format.
+//
+// Files are linted concurrently, so times are the sum across all goroutines
+// rather than wall-clock time for the whole run.
+type Profile struct {
+	mu      sync.Mutex
+	rules   map[string]*RuleProfile
+	formats map[string]*FormatProfile
+	inRules map[*core.File]time.Duration
+}
+
+// RuleProfile is the cumulative cost of a single rule.
+type RuleProfile struct {
+	Rule   string
+	Time   time.Duration
+	Calls  int
+	Alerts int
+}
+
+// FormatProfile is the cumulative cost of parsing files of a single format,
+// excluding the time spent running rules on them.
+type FormatProfile struct {
+	Format string
+	Time   time.Duration
+	Files  int
+}
+
+// ProfileReport is a snapshot of a Profile, sorted from slowest to fastest.
+type ProfileReport struct {
+	Rules   []RuleProfile
+	Formats []FormatProfile
+}
+
+func newProfile() *Profile {
+	return &Profile{
+		rules:   make(map[string]*RuleProfile),
+		formats: make(map[string]*FormatProfile),
+		inRules: make(map[*core.File]time.Duration),
+	}
+}
+
+// Profile returns the Linter's profile, or `nil` if profiling isn't enabled.
+func (l *Linter) Profile() *Profile {
+	return l.profile
+}
+
+func (p *Profile) recordRule(f *core.File, name string, elapsed time.Duration, alerts int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.rules[name]
+	if !ok {
+		entry = &RuleProfile{Rule: name}
+		p.rules[name] = entry
+	}
+
+	entry.Time += elapsed
+	entry.Calls++
+	entry.Alerts += alerts
+
+	p.inRules[f] += elapsed
+}
+
+func (p *Profile) recordFormat(f *core.File, format string, elapsed time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.formats[format]
+	if !ok {
+		entry = &FormatProfile{Format: format}
+		p.formats[format] = entry
+	}
+
+	entry.Time += elapsed - p.inRules[f]
+	entry.Files++
+
+	delete(p.inRules, f)
+}
+
+// Report returns the profile's results so far.
+func (p *Profile) Report() ProfileReport {
+	report := ProfileReport{
+		Rules:   []RuleProfile{},
+		Formats: []FormatProfile{},
+	}
+	if p == nil {
+		return report
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, entry := range p.rules {
+		report.Rules = append(report.Rules, *entry)
+	}
+	sort.Slice(report.Rules, func(i, j int) bool {
+		if report.Rules[i].Time != report.Rules[j].Time {
+			return report.Rules[i].Time > report.Rules[j].Time
+		}
+		return report.Rules[i].Rule < report.Rules[j].Rule
+	})
+
+	for _, entry := range p.formats {
+		report.Formats = append(report.Formats, *entry)
+	}
+	sort.Slice(report.Formats, func(i, j int) bool {
+		if report.Formats[i].Time != report.Formats[j].Time {
+			return report.Formats[i].Time > report.Formats[j].Time
+		}
+		return report.Formats[i].Format < report.Formats[j].Format
+	})
+
+	return report
+}
+
+// profileFormat is the name we use to report the parse time of `f`.
+func profileFormat(f *core.File, simple bool) string {
+	switch {
+	case simple:
+		return "lines"
+	case f.Format == "markup" || f.NormedExt == ".txt":
+		return f.NormedExt
+	}
+	return f.Format
+}
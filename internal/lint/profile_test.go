@@ -0,0 +1,57 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+)
+
+func TestProfile(t *testing.T) {
+	styles := t.TempDir()
+
+	rule := filepath.Join(styles, "Test", "Rule.yml")
+	if err := os.MkdirAll(filepath.Dir(rule), 0700); err != nil {
+		t.Fatal(err)
+	}
+	body := "extends: existence\nmessage: \"'%s'\"\ntokens:\n  - test\n"
+	if err := os.WriteFile(rule, []byte(body), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := core.NewConfig(&core.CLIFlags{Profile: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Paths = []string{styles}
+	cfg.GBaseStyles = []string{"Test"}
+	cfg.Styles = []string{"Test"}
+
+	linter, err := NewLinter(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = linter.LintStringWithPath("# Title\n\nA test.\n\nAnother test.\n", "test.md"); err != nil {
+		t.Fatal(err)
+	}
+
+	report := linter.Profile().Report()
+	found := false
+	for _, r := range report.Rules {
+		if r.Rule == "Test.Rule" {
+			found = true
+			if r.Alerts != 2 || r.Calls == 0 {
+				t.Errorf("unexpected profile for 'Test.Rule': %+v", r)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected 'Test.Rule' in %+v", report.Rules)
+	}
+
+	if len(report.Formats) != 1 || report.Formats[0].Format != ".md" || report.Formats[0].Files != 1 {
+		t.Errorf("unexpected formats: %+v", report.Formats)
+	}
+}
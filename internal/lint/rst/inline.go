@@ -0,0 +1,272 @@
+package rst
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v3/internal/lint/markup"
+)
+
+var (
+	reRole         = regexp.MustCompile("^:([\\w.+:-]+):`")
+	reSuffixRole   = regexp.MustCompile(`^:([\w.+:-]+):`)
+	reFootnoteRef  = regexp.MustCompile(`^\[(?:\d+|#[\w-]*|\*|[\w.-]+)\]_`)
+	reURL          = regexp.MustCompile(`^(?:https?|ftp|mailto):[^\s<>]*[^\s<>.,;:!?)\]'"]`)
+	reWordRef      = regexp.MustCompile(`^\w(?:[\w.+-]*\w)?__?`)
+	reTitledTarget = regexp.MustCompile(`(?s)^(.*?)\s*<([^<>]+)>$`)
+)
+
+// textRoles are roles whose content is prose.
+var textRoles = map[string]bool{
+	"abbr": true, "dfn": true, "guilabel": true, "menuselection": true,
+	"sub": true, "subscript": true, "sup": true, "superscript": true,
+	"t": true, "title": true, "title-reference": true, "term": true,
+}
+
+// refRoles are cross-reference roles: their content is prose only if it
+// includes an explicit title (e.g., :ref:`the docs <target>`).
+var refRoles = map[string]bool{
+	"any": true, "doc": true, "download": true, "keyword": true,
+	"numref": true, "ref": true,
+}
+
+// span appends s as an inline element with the given tag.
+func span(t *markup.Text, tag, s string, pos []int, literal bool) {
+	start := len(t.Buf)
+	if tag == "code" || literal {
+		t.Add(s, pos)
+	} else {
+		unescape(t, s, pos)
+	}
+	t.Mark(markup.Span{Tag: tag, Literal: literal}, start)
+}
+
+func unescape(t *markup.Text, s string, pos []int) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		t.Add(s[i:i+1], pos[i:])
+	}
+}
+
+// inline parses the reStructuredText inline markup in s, whose bytes were
+// found at the given source offsets.
+func inline(s string, pos []int) *markup.Text {
+	t := &markup.Text{}
+
+	i := 0
+	for i < len(s) {
+		rest := s[i:]
+		start := isStartBoundary(s, i)
+
+		if rest[0] == '\\' && len(rest) > 1 {
+			r, size := utf8.DecodeRuneInString(rest[1:])
+			if !unicode.IsSpace(r) {
+				t.Add(rest[1:1+size], pos[i+1:])
+			}
+			i += 1 + size
+			continue
+		}
+
+		if start {
+			if tag, from, to, size := inlineMarkup(rest); size > 0 {
+				if tag == "" {
+					unescape(t, s[i+from:i+to], pos[i+from:])
+				} else {
+					span(t, tag, s[i+from:i+to], pos[i+from:], false)
+				}
+				i += size
+				continue
+			}
+		}
+
+		if start || rest[0] == '[' {
+			if m := reFootnoteRef.FindString(rest); m != "" {
+				// Footnote and citation references have no prose.
+				i += len(m)
+				continue
+			}
+		}
+
+		if start {
+			if m := reURL.FindString(rest); m != "" {
+				span(t, "a", m, pos[i:], true)
+				i += len(m)
+				continue
+			} else if m := reWordRef.FindString(rest); m != "" && isEndBoundary(s, i+len(m)) {
+				// A reference like `word_` or `word__`.
+				t.Add(strings.TrimRight(m, "_"), pos[i:])
+				i += len(m)
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(rest)
+		t.Add(rest[:size], pos[i:])
+		i += size
+	}
+
+	return t
+}
+
+// inlineMarkup recognizes an inline markup construct at the start of `s`, returning
+// the tag to use, the bounds of its content, and the number of bytes consumed.
+//
+// An empty tag indicates that the content is regular text.
+func inlineMarkup(s string) (string, int, int, int) {
+	switch {
+	case strings.HasPrefix(s, "``"):
+		if end := findEnd(s, 2, "``"); end > 0 {
+			return "code", 2, end, end + 2
+		}
+	case strings.HasPrefix(s, "**"):
+		if end := findEnd(s, 2, "**"); end > 0 {
+			return "strong", 2, end, end + 2
+		}
+	case strings.HasPrefix(s, "*"):
+		if end := findEnd(s, 1, "*"); end > 0 {
+			return "em", 1, end, end + 1
+		}
+	case strings.HasPrefix(s, "|"):
+		if end := findEnd(s, 1, "|"); end > 0 {
+			size := end + 1 + suffixLen(s[end+1:], "__", "_")
+			// Substitution references are replaced by their definition,
+			// which we don't lint.
+			return "code", 1, end, size
+		}
+	case strings.HasPrefix(s, "_`"):
+		if end := findEnd(s, 2, "`"); end > 0 {
+			return "", 2, end, end + 1
+		}
+	case strings.HasPrefix(s, ":"):
+		if m := reRole.FindStringSubmatch(s); m != nil {
+			open := len(m[0])
+			if end := findEnd(s, open, "`"); end > 0 {
+				tag, from, to := role(m[1], s[open:end])
+				return tag, open + from, open + to, end + 1
+			}
+		}
+	case strings.HasPrefix(s, "`"):
+		end := findEnd(s, 1, "`")
+		if end < 0 {
+			break
+		}
+		inner := s[1:end]
+		after := s[end+1:]
+
+		if n := suffixLen(after, "__", "_"); n > 0 {
+			// A hyperlink reference: `text <url>`_
+			if m := reTitledTarget.FindStringSubmatchIndex(inner); m != nil {
+				if m[2] == m[3] {
+					return "code", 1 + m[4], 1 + m[5], end + 1 + n
+				}
+				return "a", 1 + m[2], 1 + m[3], end + 1 + n
+			}
+			return "a", 1, end, end + 1 + n
+		} else if m := reSuffixRole.FindStringSubmatch(after); m != nil {
+			tag, from, to := role(m[1], inner)
+			return tag, 1 + from, 1 + to, end + 1 + len(m[0])
+		}
+
+		// Interpreted text with the default role.
+		return "cite", 1, end, end + 1
+	}
+
+	return "", 0, 0, 0
+}
+
+// role determines how to treat interpreted text with the given role,
+// returning its tag and the bounds of its content within text.
+func role(name, text string) (string, int, int) {
+	name = strings.ToLower(name)
+	if idx := strings.LastIndex(name, ":"); idx >= 0 {
+		// Drop the domain, if any (e.g., `std:ref`).
+		switch name[:idx] {
+		case "std", "rst":
+			name = name[idx+1:]
+		}
+	}
+
+	switch {
+	case name == "emphasis":
+		return "em", 0, len(text)
+	case name == "strong":
+		return "strong", 0, len(text)
+	case textRoles[name]:
+		if m := reTitledTarget.FindStringSubmatchIndex(text); m != nil && m[3] > m[2] {
+			return "", m[2], m[3]
+		}
+		return "", 0, len(text)
+	case refRoles[name]:
+		if m := reTitledTarget.FindStringSubmatchIndex(text); m != nil && m[3] > m[2] {
+			return "", m[2], m[3]
+		}
+	}
+
+	// Code-like roles (`code`, `file`, `py:func`, etc.), as well as unknown
+	// ones, aren't linted.
+	return "code", 0, len(text)
+}
+
+// findEnd returns the index of the end-string `end` in s (searching from
+// `from`), or -1 if there isn't a valid one.
+func findEnd(s string, from int, end string) int {
+	if from >= len(s) {
+		return -1
+	}
+
+	if r, _ := utf8.DecodeRuneInString(s[from:]); unicode.IsSpace(r) {
+		// The start-string must be immediately followed by non-whitespace.
+		return -1
+	}
+
+	for i := from + 1; i <= len(s)-len(end); i++ {
+		if s[i-1] == '\\' && end != "``" {
+			continue
+		} else if !strings.HasPrefix(s[i:], end) {
+			continue
+		}
+
+		prev, _ := utf8.DecodeLastRuneInString(s[:i])
+		if unicode.IsSpace(prev) {
+			continue
+		}
+
+		if isEndBoundary(s, i+len(end)) || (end == "`" && i+len(end) < len(s) &&
+			(s[i+len(end)] == '_' || s[i+len(end)] == ':')) || end == "|" {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func suffixLen(s string, suffixes ...string) int {
+	for _, suffix := range suffixes {
+		if strings.HasPrefix(s, suffix) {
+			return len(suffix)
+		}
+	}
+	return 0
+}
+
+func isStartBoundary(s string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	return unicode.IsSpace(prev) || strings.ContainsRune(`-:/'"<([{`, prev) ||
+		unicode.In(prev, unicode.Ps, unicode.Pi, unicode.Pd)
+}
+
+func isEndBoundary(s string, i int) bool {
+	if i >= len(s) {
+		return true
+	}
+	next, _ := utf8.DecodeRuneInString(s[i:])
+	return unicode.IsSpace(next) || strings.ContainsRune(`-.,:;!?\/'")]}>`, next) ||
+		unicode.In(next, unicode.Pe, unicode.Pf, unicode.Pd, unicode.Po)
+}
@@ -0,0 +1,510 @@
+// Package rst parses reStructuredText into the blocks of text that we lint
+// (see `internal/lint/markup`).
+//
+// The blocks mirror the structure produced by docutils' `rst2html`
+// (headings, paragraphs, lists, tables, literal blocks, admonitions, etc.).
+package rst
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v3/internal/lint/markup"
+)
+
+var (
+	reBullet     = regexp.MustCompile(`^([-*+•‣⁃])(\s+|$)`)
+	reEnumerated = regexp.MustCompile(`^(\(?(?:\d+|#|[A-Za-z]|[ivxlcdm]+|[IVXLCDM]+)\)|(?:\d+|#|[A-Za-z]|[ivxlcdm]+|[IVXLCDM]+)\.)(\s+|$)`)
+	reField      = regexp.MustCompile(`^:((?:\\:|[^:])+):(\s+|$)`)
+	reLineBlock  = regexp.MustCompile(`^\|(\s+|$)`)
+	reGridTable  = regexp.MustCompile(`^\+(?:[-=]+\+)+\s*$`)
+	reSimpleRule = regexp.MustCompile(`^=+(?:\s+=+)+\s*$`)
+	reDirective  = regexp.MustCompile(`^\.\.\s+([\w:.+-]+)::(?:\s+(.*))?$`)
+	reFootnote   = regexp.MustCompile(`^\.\.\s+\[([^\]]+)\](?:\s+(.*))?$`)
+	reOption     = regexp.MustCompile(`^:([^:]+):(?:\s+(.*))?$`)
+)
+
+// adornments are the characters allowed in section titles and transitions.
+const adornments = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// admonitions are directives whose content is placed inside an `<aside>`,
+// which the linter maps to the `text.admonition` scope.
+var admonitions = map[string]bool{
+	"admonition": true, "attention": true, "caution": true, "danger": true,
+	"error": true, "hint": true, "important": true, "note": true, "tip": true,
+	"warning": true, "seealso": true, "todo": true,
+}
+
+// containers are directives whose content (and, optionally, title argument)
+// is regular body text.
+var containers = map[string]bool{
+	"topic": true, "sidebar": true, "rubric": true, "epigraph": true,
+	"highlights": true, "pull-quote": true, "container": true,
+	"compound": true, "only": true, "centered": true, "hlist": true,
+	"tab": true, "tabs": true, "group-tab": true, "tab-set": true,
+	"tab-item": true, "dropdown": true, "card": true, "grid": true,
+	"grid-item": true, "grid-item-card": true, "glossary": true,
+	"versionadded": true, "versionchanged": true, "deprecated": true,
+	"versionremoved": true, "class": true,
+}
+
+type converter struct {
+	markup.Builder
+	// styles holds the section adornment styles in order of appearance,
+	// which determines the level of each heading.
+	styles []string
+}
+
+// Parse returns the blocks of the given reStructuredText, in document order.
+func Parse(src string) []markup.Block {
+	c := converter{}
+	c.blocks(markup.Split(src))
+	return c.Blocks
+}
+
+// text emits the given lines, which contain inline markup, as a block.
+func (c *converter) text(tag string, lines []markup.Line) {
+	s, pos := markup.Join(lines)
+	c.inline(tag, s, pos)
+}
+
+func (c *converter) inline(tag, s string, pos []int) {
+	c.Emit(tag, inline(s, pos))
+}
+
+func (c *converter) blocks(lines []markup.Line) {
+	literalNext := false
+	for i := 0; i < len(lines); {
+		if markup.IsBlank(lines[i].Text) {
+			i++
+			continue
+		}
+		i, literalNext = c.block(lines, i, literalNext)
+	}
+}
+
+// block parses the block starting at `lines[i]`, returning the index of the
+// next line and whether the next indented block is a literal block.
+func (c *converter) block(lines []markup.Line, i int, literalNext bool) (int, bool) {
+	n := len(lines)
+	line := lines[i].TrimRight().Text
+
+	if markup.IndentOf(line) > 0 {
+		block, end := markup.Indented(lines, i, 1)
+		if literalNext {
+			c.Pre(block)
+		} else {
+			c.Open("blockquote")
+			c.blocks(markup.Dedent(block))
+			c.Close()
+		}
+		return end, false
+	}
+
+	switch {
+	case isExplicit(line):
+		i = c.explicit(lines, i)
+	case reGridTable.MatchString(line):
+		i = c.gridTable(lines, i)
+	case reSimpleRule.MatchString(line):
+		i = c.simpleTable(lines, i)
+	case line == "::":
+		// An expanded literal block marker, which has no text.
+		return i + 1, true
+	case isAdornment(line):
+		i = c.sectionOrTransition(lines, i)
+	case strings.HasPrefix(line, ">>> "):
+		end := i
+		for end < n && !markup.IsBlank(lines[end].Text) {
+			end++
+		}
+		c.Pre(lines[i:end])
+		i = end
+	case reBullet.MatchString(line):
+		i = c.list(lines, i, reBullet, "ul")
+	case isEnumerated(lines, i):
+		i = c.list(lines, i, reEnumerated, "ol")
+	case reField.MatchString(line):
+		i = c.fieldList(lines, i)
+	case reLineBlock.MatchString(line):
+		i = c.lineBlock(lines, i)
+	case i+1 < n && isTitleUnderline(line, lines[i+1].Text):
+		c.heading(lines[i], strings.TrimSpace(lines[i+1].Text)[:1])
+		i += 2
+	case i+1 < n && !markup.IsBlank(lines[i+1].Text) && markup.IndentOf(lines[i+1].Text) > 0:
+		i = c.definitionList(lines, i)
+	default:
+		return c.paragraph(lines, i)
+	}
+	return i, false
+}
+
+func (c *converter) paragraph(lines []markup.Line, i int) (int, bool) {
+	para := []markup.Line{}
+	for i < len(lines) && !markup.IsBlank(lines[i].Text) {
+		para = append(para, lines[i].Trim())
+		i++
+	}
+
+	text, pos := markup.Join(para)
+
+	literal := strings.HasSuffix(text, "::")
+	if literal {
+		switch {
+		case text == "::":
+			text = ""
+		case strings.HasSuffix(text, " ::"), strings.HasSuffix(text, "\n::"):
+			text = strings.TrimRight(strings.TrimSuffix(text, "::"), " \n")
+		default:
+			text = strings.TrimSuffix(text, ":")
+		}
+	}
+
+	c.inline("p", text, pos[:len(text)])
+	return i, literal
+}
+
+func (c *converter) heading(title markup.Line, style string) {
+	level := 0
+	for idx, s := range c.styles {
+		if s == style {
+			level = idx + 1
+			break
+		}
+	}
+
+	if level == 0 {
+		c.styles = append(c.styles, style)
+		level = len(c.styles)
+	}
+	level = min(level, 6)
+
+	c.text("h"+string(rune('0'+level)), []markup.Line{title.Trim()})
+}
+
+func (c *converter) sectionOrTransition(lines []markup.Line, i int) int {
+	line := lines[i].TrimRight().Text
+	if i+2 < len(lines) && !markup.IsBlank(lines[i+1].Text) {
+		under := lines[i+2].TrimRight().Text
+		if isAdornment(under) && under[0] == line[0] {
+			// An overlined title:
+			//
+			// =====
+			// Title
+			// =====
+			c.heading(lines[i+1], "o"+line[:1])
+			return i + 3
+		}
+	}
+
+	// A transition (or a stray adornment line), which has no text.
+	return i + 1
+}
+
+// list parses a bullet or enumerated list starting at `lines[i]`.
+func (c *converter) list(lines []markup.Line, i int, marker *regexp.Regexp, tag string) int {
+	c.Open(tag)
+	defer c.Close()
+
+	n := len(lines)
+	for i < n {
+		m := marker.FindString(lines[i].Text)
+		if m == "" {
+			break
+		}
+
+		item, end := listItem(lines, i, len(m))
+		c.Open("li")
+		c.blocks(item)
+		c.Close()
+		i = end
+
+		// Blank lines are allowed between items.
+		next := i
+		for next < n && markup.IsBlank(lines[next].Text) {
+			next++
+		}
+		if next < n && marker.MatchString(lines[next].Text) && markup.IndentOf(lines[next].Text) == 0 {
+			i = next
+		} else {
+			break
+		}
+	}
+
+	return i
+}
+
+// listItem returns the body of the item (or field) whose first line is
+// `lines[i]` and whose marker is `width` bytes long.
+func listItem(lines []markup.Line, i, width int) ([]markup.Line, int) {
+	first := lines[i].From(width).Trim()
+
+	rest, end := markup.Indented(lines, i+1, 1)
+	body := append([]markup.Line{first}, markup.Dedent(rest)...)
+
+	return body, end
+}
+
+func (c *converter) fieldList(lines []markup.Line, i int) int {
+	for i < len(lines) {
+		m := reField.FindString(lines[i].Text)
+		if m == "" {
+			break
+		}
+
+		// We skip the field name (e.g., `:Author:` or `:param x:`), which
+		// isn't prose.
+		body, end := listItem(lines, i, len(m))
+		c.blocks(body)
+
+		i = end
+		for i < len(lines) && markup.IsBlank(lines[i].Text) {
+			i++
+		}
+	}
+	return i
+}
+
+func (c *converter) lineBlock(lines []markup.Line, i int) int {
+	text := []markup.Line{}
+	for i < len(lines) && !markup.IsBlank(lines[i].Text) {
+		l := lines[i].Trim()
+		if m := reLineBlock.FindString(l.Text); m != "" {
+			l = l.From(len(m)).Trim()
+		}
+		text = append(text, l)
+		i++
+	}
+
+	c.text("p", text)
+	return i
+}
+
+func (c *converter) definitionList(lines []markup.Line, i int) int {
+	c.Open("dl")
+	defer c.Close()
+
+	for i+1 < len(lines) && !markup.IsBlank(lines[i].Text) && markup.IndentOf(lines[i].Text) == 0 &&
+		!markup.IsBlank(lines[i+1].Text) && markup.IndentOf(lines[i+1].Text) > 0 {
+		term := lines[i].Trim()
+		if idx := strings.Index(term.Text, " : "); idx > 0 {
+			// term : classifier
+			term.Text = term.Text[:idx]
+		}
+
+		body, end := markup.Indented(lines, i+1, 1)
+		c.text("dt", []markup.Line{term})
+		c.Open("dd")
+		c.blocks(markup.Dedent(body))
+		c.Close()
+
+		i = end
+		for i < len(lines) && markup.IsBlank(lines[i].Text) {
+			i++
+		}
+	}
+
+	return i
+}
+
+// explicit handles explicit markup blocks: directives, comments, footnotes,
+// hyperlink targets, and substitution definitions.
+func (c *converter) explicit(lines []markup.Line, i int) int {
+	header := lines[i].TrimRight()
+
+	body, end := markup.Indented(lines, i+1, 1)
+	body = markup.Dedent(body)
+
+	if m := reDirective.FindStringSubmatchIndex(header.Text); m != nil {
+		arg := markup.Line{Off: header.Off + len(header.Text)}
+		if m[4] >= 0 {
+			arg = header.From(m[4]).Trim()
+		}
+		c.directive(strings.ToLower(header.Text[m[2]:m[3]]), arg, body, lines[i:end])
+		return end
+	}
+
+	if m := reFootnote.FindStringSubmatchIndex(header.Text); m != nil {
+		content := body
+		if m[4] >= 0 && m[5] > m[4] {
+			content = append([]markup.Line{header.From(m[4])}, body...)
+		}
+		c.blocks(content)
+		return end
+	}
+
+	rest := header.From(2).Trim()
+	if strings.HasPrefix(rest.Text, "_") || strings.HasPrefix(rest.Text, "|") {
+		// Hyperlink targets and substitution definitions.
+		c.Pre(lines[i:end])
+		return end
+	}
+
+	// A comment, which may be a comment control (e.g., `.. vale off`).
+	parts := []string{rest.Text}
+	for _, l := range body {
+		parts = append(parts, l.Text)
+	}
+
+	c.Comment(strings.TrimSpace(strings.Join(parts, " ")), header.Off)
+
+	return end
+}
+
+func (c *converter) directive(name string, arg markup.Line, body, raw []markup.Line) {
+	options, content := splitOptions(body)
+
+	switch {
+	case admonitions[name]:
+		if arg.Text != "" {
+			// The argument is the start of the first paragraph, which may
+			// continue on the following lines.
+			if len(options) > 0 || len(body) == 0 || markup.IsBlank(body[0].Text) {
+				content = append([]markup.Line{arg, {}}, content...)
+			} else {
+				content = append([]markup.Line{arg}, content...)
+			}
+		}
+		c.Open("aside")
+		c.blocks(content)
+		c.Close()
+	case containers[name]:
+		if strings.HasPrefix(name, "version") || name == "deprecated" {
+			// The first argument is a version number.
+			if idx := strings.Index(arg.Text, " "); idx >= 0 {
+				arg = arg.From(idx + 1).Trim()
+			} else {
+				arg.Text = ""
+			}
+		}
+		if arg.Text != "" && name != "only" && name != "class" {
+			c.text("p", []markup.Line{arg})
+		}
+		c.blocks(content)
+	case name == "image":
+		c.image(options)
+	case name == "figure":
+		c.image(options)
+		if len(content) > 0 {
+			caption, end := firstParagraph(content)
+			c.text("figcaption", caption)
+			c.blocks(content[end:])
+		}
+	case name == "table":
+		c.Open("table")
+		c.caption(arg)
+		c.Close()
+		c.blocks(content)
+	case name == "list-table":
+		c.listTable(arg, options, content)
+	case name == "csv-table":
+		c.csvTable(arg, options, content)
+	default:
+		// Code blocks, `toctree`, `include`, `raw`, and any other directive
+		// that we don't know how to handle.
+		c.Pre(raw)
+	}
+}
+
+// image emits an image's alternative text, which isn't inline markup.
+func (c *converter) image(options map[string][]markup.Line) {
+	if alt, ok := options["alt"]; ok {
+		c.Alt(markup.Join(alt))
+	}
+}
+
+// splitOptions separates a directive's options (`:name: value`) from its
+// content.
+func splitOptions(body []markup.Line) (map[string][]markup.Line, []markup.Line) {
+	options := map[string][]markup.Line{}
+
+	i := 0
+	last := ""
+	for i < len(body) {
+		l := body[i]
+		if m := reOption.FindStringSubmatchIndex(l.Text); m != nil && !isRoleStart(l.Text) {
+			last = strings.ToLower(l.Text[m[2]:m[3]])
+
+			value := markup.Line{Off: l.Off + len(l.Text)}
+			if m[4] >= 0 {
+				value = l.From(m[4]).Trim()
+			}
+			options[last] = []markup.Line{value}
+		} else if last != "" && markup.IndentOf(l.Text) > 0 && !markup.IsBlank(l.Text) {
+			options[last] = append(options[last], l.Trim())
+		} else {
+			break
+		}
+		i++
+	}
+
+	for i < len(body) && markup.IsBlank(body[i].Text) {
+		i++
+	}
+
+	return options, body[i:]
+}
+
+// option returns the value of a directive's option.
+func option(options map[string][]markup.Line, name string) string {
+	parts := []string{}
+	for _, l := range options[name] {
+		parts = append(parts, l.Text)
+	}
+	return strings.Join(parts, " ")
+}
+
+func firstParagraph(lines []markup.Line) ([]markup.Line, int) {
+	i := 0
+	for i < len(lines) && markup.IsBlank(lines[i].Text) {
+		i++
+	}
+
+	para := []markup.Line{}
+	for i < len(lines) && !markup.IsBlank(lines[i].Text) {
+		para = append(para, lines[i].Trim())
+		i++
+	}
+
+	return para, i
+}
+
+func isExplicit(line string) bool {
+	return line == ".." || strings.HasPrefix(line, ".. ")
+}
+
+func isRoleStart(line string) bool {
+	m := reOption.FindStringSubmatchIndex(line)
+	return m != nil && m[3]+1 < len(line) && line[m[3]+1] == '`'
+}
+
+// isEnumerated reports whether `lines[i]` starts an enumerated list item.
+//
+// To avoid treating sentences like "A. Smith wrote ..." as lists, the next
+// line must be blank, indented, or another item.
+func isEnumerated(lines []markup.Line, i int) bool {
+	if !reEnumerated.MatchString(lines[i].Text) {
+		return false
+	} else if i+1 == len(lines) {
+		return true
+	}
+	next := lines[i+1].Text
+	return markup.IsBlank(next) || markup.IndentOf(next) > 0 || reEnumerated.MatchString(next)
+}
+
+func isAdornment(line string) bool {
+	if utf8.RuneCountInString(line) < 2 || !strings.ContainsRune(adornments, rune(line[0])) {
+		return false
+	}
+	return strings.Trim(line, line[:1]) == ""
+}
+
+func isTitleUnderline(title, under string) bool {
+	under = strings.TrimRight(under, " \t\r")
+	if markup.IndentOf(under) > 0 || !isAdornment(under) {
+		return false
+	}
+	size := utf8.RuneCountInString(under)
+	return size >= utf8.RuneCountInString(strings.TrimSpace(title)) || size >= 4
+}
@@ -0,0 +1,144 @@
+package rst
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/errata-ai/vale/v3/internal/lint/markup"
+)
+
+// describe returns a summary of each block: its tags, followed by its text.
+func describe(blocks []markup.Block) []string {
+	out := []string{}
+	for _, b := range blocks {
+		out = append(out, strings.Join(b.Tags, ">")+": "+string(b.Text))
+	}
+	return out
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name     string
+		src      string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "headings",
+			src:      "Title\n=====\n\nSection\n-------\n\nAnother\n=======\n",
+			contains: []string{"h1: Title", "h2: Section", "h1: Another"},
+		},
+		{
+			name:     "literal",
+			src:      "Example::\n\n    XXX: code\n\nDone.\n",
+			contains: []string{"p: Example:", "pre:     XXX: code", "p: Done."},
+		},
+		{
+			name:     "admonition",
+			src:      ".. note:: First.\n\n   Second.\n",
+			contains: []string{"aside>p: First.", "aside>p: Second."},
+		},
+		{
+			name:     "unknown directive",
+			src:      ".. custom-thing:: arg\n   :option: 1\n\n   Body.\n\nAfter.\n",
+			contains: []string{"pre: .. custom-thing:: arg\n   :option: 1\n\n   Body.", "p: After."},
+			excludes: []string{"p: Body."},
+		},
+		{
+			name:     "comment",
+			src:      ".. vale off\n\nText.\n",
+			contains: []string{"!--: vale off"},
+		},
+		{
+			name:     "list",
+			src:      "- One.\n- Two\n  continued.\n",
+			contains: []string{"ul>li>p: One.", "ul>li>p: Two\ncontinued."},
+		},
+		{
+			name:     "simple table",
+			src:      "===  ===\nA    B\n===  ===\n1    2\n===  ===\n",
+			contains: []string{"table>tr>th>p: A", "table>tr>th>p: B", "table>tr>td>p: 1", "table>tr>td>p: 2"},
+		},
+		{
+			name:     "grid table",
+			src:      "+-----+-----+\n| Héa | B   |\n+=====+=====+\n| 1   | 2   |\n+-----+-----+\n",
+			contains: []string{"table>tr>th>p: Héa", "table>tr>th>p: B", "table>tr>td>p: 1", "table>tr>td>p: 2"},
+		},
+		{
+			name:     "csv table",
+			src:      ".. csv-table:: Title\n   :header: \"X\", \"Y\"\n\n   1, \"two, three\"\n",
+			contains: []string{"table>caption: Title", "table>tr>th: X", "table>tr>td: 1", "table>tr>td: two, three"},
+		},
+		{
+			name:     "image",
+			src:      ".. image:: a.png\n   :alt: A picture\n",
+			contains: []string{"img: A picture"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			blocks := Parse(tc.src)
+			got := describe(blocks)
+
+			for _, s := range tc.contains {
+				found := false
+				for _, b := range got {
+					found = found || b == s
+				}
+				if !found {
+					t.Errorf("expected %q in %q", s, got)
+				}
+			}
+			for _, s := range tc.excludes {
+				for _, b := range got {
+					if b == s {
+						t.Errorf("unexpected %q in %q", s, got)
+					}
+				}
+			}
+
+			for _, b := range blocks {
+				if b.Comment {
+					continue
+				}
+				for i, c := range b.Text {
+					if c != '\n' && tc.src[b.Pos[i]] != c {
+						t.Errorf("%q: byte %d (%q) maps to %q", b.Text, i, c, tc.src[b.Pos[i]])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestInline(t *testing.T) {
+	src := "A ``literal``, *em*, :code:`x`, `a link <https://example.com>`_, and https://example.com."
+
+	pos := make([]int, len(src))
+	for i := range pos {
+		pos[i] = i
+	}
+	txt := inline(src, pos)
+
+	expected := "A literal, em, x, a link, and https://example.com."
+	if string(txt.Buf) != expected {
+		t.Fatalf("expected %q, got %q", expected, txt.Buf)
+	}
+
+	tags := []string{}
+	for _, s := range txt.Spans {
+		tags = append(tags, s.Tag+":"+string(txt.Buf[s.Start:s.End]))
+	}
+
+	spans := "code:literal em:em code:x a:a link a:https://example.com"
+	if strings.Join(tags, " ") != spans {
+		t.Errorf("expected %q, got %q", spans, strings.Join(tags, " "))
+	}
+
+	for i, c := range txt.Buf {
+		if src[txt.Pos[i]] != c {
+			t.Errorf("byte %d (%q) maps to %q", i, c, src[txt.Pos[i]])
+		}
+	}
+}
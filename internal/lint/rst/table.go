@@ -0,0 +1,334 @@
+package rst
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/errata-ai/vale/v3/internal/lint/markup"
+)
+
+var reGridSeparator = regexp.MustCompile(`^[-=]+$`)
+
+// gridTable parses a grid table:
+//
+//	+--------+--------+
+//	| Header | Header |
+//	+========+========+
+//	| Cell   | Cell   |
+//	+--------+--------+
+//
+// Column boundaries are taken from the table's top border. Cells that span
+// multiple columns are detected by the absence of a `|` at a boundary.
+func (c *converter) gridTable(lines []markup.Line, i int) int {
+	top := []rune(lines[i].TrimRight().Text)
+
+	bounds := []int{}
+	for idx, r := range top {
+		if r == '+' {
+			bounds = append(bounds, idx)
+		}
+	}
+
+	header := false
+	for end := i + 1; end < len(lines); end++ {
+		l := strings.TrimSpace(lines[end].Text)
+		if l == "" || (l[0] != '+' && l[0] != '|') {
+			break
+		} else if l[0] == '+' && strings.Contains(l, "=") && reGridTable.MatchString(l) {
+			header = true
+		}
+	}
+
+	cells := map[int][]markup.Line{}
+	order := []int{}
+	row := [][]markup.Line{}
+
+	flushCell := func(col int) {
+		if text, ok := cells[col]; ok {
+			row = append(row, markup.Dedent(text))
+			delete(cells, col)
+		}
+	}
+
+	inHeader := header
+
+	c.Open("table")
+	defer c.Close()
+
+	j := i + 1
+	for ; j < len(lines); j++ {
+		l := lines[j].TrimRight()
+		trimmed := strings.TrimSpace(l.Text)
+		if trimmed == "" || (trimmed[0] != '+' && trimmed[0] != '|') {
+			break
+		}
+
+		for _, seg := range gridSegments(l, bounds) {
+			text := strings.TrimSpace(seg.text.Text)
+			if trimmed[0] == '+' && reGridSeparator.MatchString(text) {
+				// The cell above this (partial) border is complete.
+				flushCell(seg.col)
+				continue
+			}
+
+			if _, ok := cells[seg.col]; !ok {
+				order = append(order, seg.col)
+			}
+			cells[seg.col] = append(cells[seg.col], seg.text)
+		}
+
+		if reGridTable.MatchString(trimmed) {
+			for _, col := range order {
+				flushCell(col)
+			}
+			order = []int{}
+
+			if len(row) > 0 {
+				c.tableRow(row, inHeader)
+			}
+			row = [][]markup.Line{}
+
+			if strings.Contains(trimmed, "=") {
+				inHeader = false
+			}
+		}
+	}
+
+	return j
+}
+
+type gridSegment struct {
+	col  int
+	text markup.Line
+}
+
+// gridSegments splits a row of a grid table into its cells, where bounds are
+// the (rune) indices of the column boundaries.
+func gridSegments(l markup.Line, bounds []int) []gridSegment {
+	segs := []gridSegment{}
+	if len(bounds) < 2 {
+		return segs
+	}
+
+	runes := []rune(l.Text)
+	offsets := markup.RuneOffsets(l.Text)
+
+	start, col := bounds[0], 0
+	for k := 1; k < len(bounds); k++ {
+		b := bounds[k]
+
+		last := k == len(bounds)-1
+		if !last && (b >= len(runes) || (runes[b] != '|' && runes[b] != '+')) {
+			// This cell spans multiple columns.
+			continue
+		}
+
+		if start+1 < len(runes) {
+			from, to := offsets[start+1], offsets[min(b, len(runes))]
+			segs = append(segs, gridSegment{col, markup.Line{Text: l.Text[from:to], Off: l.Off + from}})
+		}
+		start, col = b, k
+	}
+
+	return segs
+}
+
+// simpleTable parses a simple table:
+//
+//	=====  =====
+//	Col 1  Col 2
+//	=====  =====
+//	1      Second column of row 1.
+//	2      Second column of row 2.
+//	=====  =====
+func (c *converter) simpleTable(lines []markup.Line, i int) int {
+	border := []rune(lines[i].TrimRight().Text)
+
+	columns := [][2]int{}
+	for idx := 0; idx < len(border); idx++ {
+		if border[idx] == '=' && (idx == 0 || border[idx-1] != '=') {
+			columns = append(columns, [2]int{idx, idx})
+		}
+		if border[idx] == '=' {
+			columns[len(columns)-1][1] = idx + 1
+		}
+	}
+
+	borders := []int{i}
+
+	j := i + 1
+	for ; j < len(lines); j++ {
+		if reSimpleRule.MatchString(lines[j].TrimRight().Text) {
+			borders = append(borders, j)
+			if j+1 == len(lines) || markup.IsBlank(lines[j+1].Text) {
+				j++
+				break
+			}
+		}
+	}
+
+	headerEnd := -1
+	if len(borders) > 2 {
+		headerEnd = borders[1]
+	}
+
+	c.Open("table")
+	defer c.Close()
+
+	var row [][]markup.Line
+	flush := func(header bool) {
+		if row == nil {
+			return
+		}
+		cells := make([][]markup.Line, len(row))
+		for k, text := range row {
+			cells[k] = markup.Dedent(text)
+		}
+		c.tableRow(cells, header)
+		row = nil
+	}
+
+	for k := i + 1; k < j; k++ {
+		raw := lines[k].TrimRight()
+		header := headerEnd > k
+
+		if markup.IsBlank(raw.Text) || reSimpleRule.MatchString(raw.Text) ||
+			strings.Trim(raw.Text, "- ") == "" {
+			flush(header || k == headerEnd)
+			continue
+		}
+
+		size := utf8.RuneCountInString(raw.Text)
+		offsets := markup.RuneOffsets(raw.Text)
+
+		texts := make([]markup.Line, len(columns))
+		for col, span := range columns {
+			if span[0] >= size {
+				texts[col] = markup.Line{Off: raw.Off + len(raw.Text)}
+				continue
+			}
+
+			end := size
+			if col+1 < len(columns) {
+				end = min(columns[col+1][0], size)
+			}
+
+			from, to := offsets[span[0]], offsets[end]
+			texts[col] = markup.Line{Text: raw.Text[from:to], Off: raw.Off + from}
+		}
+
+		if row != nil && strings.TrimSpace(texts[0].Text) == "" {
+			// A continuation of the previous row.
+			for col, text := range texts {
+				row[col] = append(row[col], text)
+			}
+			continue
+		}
+
+		flush(header)
+		row = make([][]markup.Line, len(columns))
+		for col, text := range texts {
+			row[col] = []markup.Line{text}
+		}
+	}
+	flush(false)
+
+	return j
+}
+
+// listTable parses a `list-table` directive, whose content is a two-level
+// bullet list.
+func (c *converter) listTable(title markup.Line, options map[string][]markup.Line, content []markup.Line) {
+	headerRows, _ := strconv.Atoi(option(options, "header-rows"))
+
+	c.Open("table")
+	defer c.Close()
+
+	c.caption(title)
+	for r, item := range bulletItems(content) {
+		c.tableRow(bulletItems(item), r < headerRows)
+	}
+}
+
+// csvTable parses a `csv-table` directive.
+func (c *converter) csvTable(title markup.Line, options map[string][]markup.Line, content []markup.Line) {
+	delim := ','
+	switch d := option(options, "delim"); d {
+	case "":
+	case "tab":
+		delim = '\t'
+	case "space":
+		delim = ' '
+	default:
+		delim = []rune(d)[0]
+	}
+
+	c.Open("table")
+	defer c.Close()
+
+	c.caption(title)
+	if header := options["header"]; len(header) > 0 {
+		c.csvRows(header, delim, -1)
+	}
+
+	headerRows, _ := strconv.Atoi(option(options, "header-rows"))
+	c.csvRows(content, delim, headerRows)
+}
+
+// csvRows emits the records of a CSV table, the first `headerRows` of which
+// are header rows (or all of them, if `headerRows` is negative).
+func (c *converter) csvRows(lines []markup.Line, delim rune, headerRows int) {
+	s, pos := markup.Join(lines)
+	for r, record := range markup.ReadCSV(s, pos, delim) {
+		tag := "td"
+		if headerRows < 0 || r < headerRows {
+			tag = "th"
+		}
+
+		c.Open("tr")
+		for _, field := range record {
+			c.inline(tag, field.Text, field.Pos)
+		}
+		c.Close()
+	}
+}
+
+func (c *converter) caption(title markup.Line) {
+	if title.Text != "" {
+		c.text("caption", []markup.Line{title})
+	}
+}
+
+func (c *converter) tableRow(cells [][]markup.Line, header bool) {
+	tag := "td"
+	if header {
+		tag = "th"
+	}
+
+	c.Open("tr")
+	for _, cell := range cells {
+		c.Open(tag)
+		c.blocks(cell)
+		c.Close()
+	}
+	c.Close()
+}
+
+// bulletItems returns the body of each item in a bullet list.
+func bulletItems(lines []markup.Line) [][]markup.Line {
+	items := [][]markup.Line{}
+	for i := 0; i < len(lines); {
+		m := reBullet.FindString(lines[i].Text)
+		if m == "" || markup.IndentOf(lines[i].Text) > 0 {
+			i++
+			continue
+		}
+
+		item, end := listItem(lines, i, len(m))
+		items = append(items, item)
+		i = end
+	}
+	return items
+}
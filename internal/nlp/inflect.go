@@ -0,0 +1,241 @@
+package nlp
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// InflectionTags are the Penn Treebank tags of the inflected forms that
+// `Inflect` can produce.
+var InflectionTags = []string{"NNS", "VBZ", "VBD", "VBN", "VBG"}
+
+var inflectable = regexp.MustCompile(`^[A-Za-z]+$`)
+
+// baseTags are the Penn Treebank tags of the (uninflected) verbs and nouns
+// that `CanInflect` accepts.
+var baseTags = map[string]bool{"NN": true, "NNP": true, "VB": true, "VBP": true}
+
+// irregularVerbs maps a verb to its past tense and past participle.
+var irregularVerbs = map[string][2]string{
+	"arise": {"arose", "arisen"}, "be": {"was", "been"},
+	"become": {"became", "become"}, "begin": {"began", "begun"},
+	"break": {"broke", "broken"}, "bring": {"brought", "brought"},
+	"build": {"built", "built"}, "buy": {"bought", "bought"},
+	"catch": {"caught", "caught"}, "choose": {"chose", "chosen"},
+	"come": {"came", "come"}, "cut": {"cut", "cut"}, "do": {"did", "done"},
+	"draw": {"drew", "drawn"}, "drive": {"drove", "driven"},
+	"eat": {"ate", "eaten"}, "fall": {"fell", "fallen"},
+	"feel": {"felt", "felt"}, "find": {"found", "found"},
+	"fly": {"flew", "flown"}, "forbid": {"forbade", "forbidden"},
+	"forget": {"forgot", "forgotten"}, "get": {"got", "gotten"},
+	"give": {"gave", "given"}, "go": {"went", "gone"},
+	"grow": {"grew", "grown"}, "have": {"had", "had"},
+	"hide": {"hid", "hidden"}, "hold": {"held", "held"},
+	"keep": {"kept", "kept"}, "know": {"knew", "known"},
+	"lay": {"laid", "laid"}, "lead": {"led", "led"},
+	"leave": {"left", "left"}, "lend": {"lent", "lent"},
+	"let": {"let", "let"}, "lose": {"lost", "lost"},
+	"make": {"made", "made"}, "mean": {"meant", "meant"},
+	"meet": {"met", "met"}, "overwrite": {"overwrote", "overwritten"},
+	"override": {"overrode", "overridden"}, "pay": {"paid", "paid"},
+	"put": {"put", "put"}, "read": {"read", "read"},
+	"rewrite": {"rewrote", "rewritten"}, "ride": {"rode", "ridden"},
+	"rise": {"rose", "risen"}, "run": {"ran", "run"},
+	"say": {"said", "said"}, "see": {"saw", "seen"},
+	"seek": {"sought", "sought"}, "sell": {"sold", "sold"},
+	"send": {"sent", "sent"}, "set": {"set", "set"},
+	"show": {"showed", "shown"}, "shut": {"shut", "shut"},
+	"sit": {"sat", "sat"}, "speak": {"spoke", "spoken"},
+	"spend": {"spent", "spent"}, "split": {"split", "split"},
+	"stand": {"stood", "stood"}, "take": {"took", "taken"},
+	"teach": {"taught", "taught"}, "tell": {"told", "told"},
+	"think": {"thought", "thought"}, "throw": {"threw", "thrown"},
+	"understand": {"understood", "understood"}, "undo": {"undid", "undone"},
+	"wear": {"wore", "worn"}, "win": {"won", "won"},
+	"write": {"wrote", "written"},
+}
+
+// stressedFinal lists multi-syllable verbs whose final syllable is stressed,
+// and so doubles its final consonant (e.g., "begin" -> "beginning").
+var stressedFinal = map[string]bool{
+	"abhor": true, "acquit": true, "admit": true, "allot": true,
+	"begin": true, "commit": true, "compel": true, "concur": true,
+	"confer": true, "control": true, "defer": true, "deter": true,
+	"embed": true, "emit": true, "equip": true, "expel": true,
+	"forbid": true, "forget": true, "incur": true, "infer": true,
+	"occur": true, "omit": true, "patrol": true, "permit": true,
+	"prefer": true, "propel": true, "rebut": true, "recur": true,
+	"refer": true, "regret": true, "submit": true, "transfer": true,
+	"transmit": true, "unzip": true, "upset": true,
+}
+
+// irregularForms maps a word to its remaining irregular forms (e.g., a
+// verb's `-s` form or a noun's plural).
+var irregularForms = map[string]map[string]string{
+	"be":    {"VBZ": "is"},
+	"have":  {"VBZ": "has"},
+	"do":    {"VBZ": "does"},
+	"go":    {"VBZ": "goes"},
+	"child": {"NNS": "children"}, "person": {"NNS": "people"},
+	"man": {"NNS": "men"}, "woman": {"NNS": "women"},
+	"foot": {"NNS": "feet"}, "tooth": {"NNS": "teeth"},
+	"mouse": {"NNS": "mice"}, "datum": {"NNS": "data"},
+	"criterion": {"NNS": "criteria"}, "phenomenon": {"NNS": "phenomena"},
+	"analysis": {"NNS": "analyses"}, "basis": {"NNS": "bases"},
+}
+
+// CanInflect reports whether `word` is a single verb or noun that `Inflect`
+// can handle.
+//
+// Other words (e.g., "us", "a", or "it") are rejected since their "inflected"
+// forms ("uses", "as", "its") are unrelated words.
+func CanInflect(word string) bool {
+	if !inflectable.MatchString(word) {
+		return false
+	}
+
+	lower := strings.ToLower(word)
+	if _, ok := irregularVerbs[lower]; ok {
+		return true
+	} else if _, ok = irregularForms[lower]; ok {
+		return true
+	}
+
+	return baseTags[doTag([]string{lower})[0].Tag]
+}
+
+// Inflect returns the form of the English word `word` (a verb or noun in its
+// base form) for the given Penn Treebank tag: "NNS" (plural noun), "VBZ"
+// (third-person singular), "VBD" (past tense), "VBN" (past participle), or
+// "VBG" (gerund).
+//
+// For multi-word phrases (e.g., "make use of"), only the first word is
+// inflected. Any other tag, or a word we can't inflect, returns `word`
+// unchanged.
+func Inflect(word, tag string) string {
+	head, rest, _ := strings.Cut(word, " ")
+	if rest != "" {
+		return Inflect(head, tag) + " " + rest
+	} else if !inflectable.MatchString(word) {
+		return word
+	}
+
+	lower := strings.ToLower(word)
+	form := inflect(lower, tag)
+
+	return matchCase(word, form)
+}
+
+// Inflections returns all of the distinct inflected forms of `word`, mapped to
+// the tags they represent.
+func Inflections(word string) map[string][]string {
+	forms := map[string][]string{}
+	for _, tag := range InflectionTags {
+		if form := Inflect(word, tag); form != word {
+			forms[form] = append(forms[form], tag)
+		}
+	}
+	return forms
+}
+
+func inflect(word, tag string) string {
+	if forms, ok := irregularForms[word]; ok {
+		if form, found := forms[tag]; found {
+			return form
+		} else if form, found = forms["VBZ"]; found && tag == "NNS" {
+			// A verb's `-s` form is the same regardless of how it's tagged.
+			return form
+		}
+	}
+
+	if forms, ok := irregularVerbs[word]; ok {
+		switch tag {
+		case "VBD":
+			return forms[0]
+		case "VBN":
+			return forms[1]
+		}
+	}
+
+	switch tag {
+	case "NNS", "VBZ":
+		switch {
+		case hasAnySuffix(word, []string{"s", "x", "z", "ch", "sh"}):
+			return word + "es"
+		case consonantY(word):
+			return word[:len(word)-1] + "ies"
+		}
+		return word + "s"
+	case "VBD", "VBN":
+		switch {
+		case strings.HasSuffix(word, "e"):
+			return word + "d"
+		case consonantY(word):
+			return word[:len(word)-1] + "ied"
+		case doublesFinal(word):
+			return word + word[len(word)-1:] + "ed"
+		}
+		return word + "ed"
+	case "VBG":
+		switch {
+		case strings.HasSuffix(word, "ie"):
+			return word[:len(word)-2] + "ying"
+		case hasAnySuffix(word, []string{"ee", "ye", "oe"}):
+			return word + "ing"
+		case strings.HasSuffix(word, "e") && len(word) > 2:
+			return word[:len(word)-1] + "ing"
+		case doublesFinal(word):
+			return word + word[len(word)-1:] + "ing"
+		}
+		return word + "ing"
+	}
+
+	return word
+}
+
+func isVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+func consonantY(word string) bool {
+	n := len(word)
+	return n > 1 && word[n-1] == 'y' && !isVowel(word[n-2])
+}
+
+// doublesFinal reports whether a word's final consonant is doubled before a
+// suffix (e.g., "stop" -> "stopped").
+//
+// We do this for single-syllable words ending in consonant-vowel-consonant
+// and, since doing so for longer words depends on stress (e.g., "prefer" ->
+// "preferred" vs. "open" -> "opened"), for those listed in `stressedFinal`.
+func doublesFinal(word string) bool {
+	n := len(word)
+	if stressedFinal[word] {
+		return true
+	} else if n < 3 || strings.IndexByte("wxy", word[n-1]) >= 0 {
+		return false
+	} else if isVowel(word[n-1]) || !isVowel(word[n-2]) || isVowel(word[n-3]) {
+		return false
+	}
+
+	groups := 0
+	for i := 0; i < n; i++ {
+		if isVowel(word[i]) && (i == 0 || !isVowel(word[i-1])) {
+			groups++
+		}
+	}
+	return groups == 1
+}
+
+// matchCase applies the case of `original` (lower, Title, or UPPER) to
+// `word`.
+func matchCase(original, word string) string {
+	switch {
+	case strings.ToUpper(original) == original:
+		return strings.ToUpper(word)
+	case unicode.IsUpper(rune(original[0])):
+		return strings.ToUpper(word[:1]) + word[1:]
+	}
+	return word
+}
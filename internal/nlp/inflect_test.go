@@ -0,0 +1,58 @@
+package nlp
+
+import "testing"
+
+func TestInflect(t *testing.T) {
+	cases := []struct {
+		word     string
+		tag      string
+		expected string
+	}{
+		{"utilize", "VBZ", "utilizes"},
+		{"utilize", "VBD", "utilized"},
+		{"utilize", "VBG", "utilizing"},
+		{"stop", "VBD", "stopped"},
+		{"stop", "VBG", "stopping"},
+		{"open", "VBD", "opened"},
+		{"apply", "VBZ", "applies"},
+		{"apply", "VBD", "applied"},
+		{"play", "VBD", "played"},
+		{"die", "VBG", "dying"},
+		{"see", "VBG", "seeing"},
+		{"see", "VBN", "seen"},
+		{"push", "VBZ", "pushes"},
+		{"child", "NNS", "children"},
+		{"Begin", "VBD", "Began"},
+		{"begin", "VBG", "beginning"},
+		{"begin", "VBN", "begun"},
+		{"commit", "VBD", "committed"},
+		{"prefer", "VBG", "preferring"},
+		{"refer", "VBZ", "refers"},
+		{"forget", "VBG", "forgetting"},
+		{"visit", "VBD", "visited"},
+		{"open", "VBG", "opening"},
+		{"utilize", "NN", "utilize"},
+		{"make use of", "VBZ", "makes use of"},
+		{"C++", "VBZ", "C++"},
+	}
+
+	for _, c := range cases {
+		if actual := Inflect(c.word, c.tag); actual != c.expected {
+			t.Errorf("Inflect(%q, %q): expected %q, got %q", c.word, c.tag, c.expected, actual)
+		}
+	}
+}
+
+func TestCanInflect(t *testing.T) {
+	for _, word := range []string{"utilize", "commence", "child", "go", "Begin"} {
+		if !CanInflect(word) {
+			t.Errorf("expected %q to be inflectable", word)
+		}
+	}
+
+	for _, word := range []string{"us", "a", "it", "simply", "C++", "make use of"} {
+		if CanInflect(word) {
+			t.Errorf("expected %q not to be inflectable", word)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeaderRE = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// LineRange is an inclusive, 1-based range of lines.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// Contains returns true if `line` falls inside the range.
+func (r LineRange) Contains(line int) bool {
+	return line >= r.Start && line <= r.End
+}
+
+// GitChanges returns the lines that have been added or modified in the
+// working tree relative to the given revision, keyed by absolute file path.
+//
+// Untracked (but not ignored) files are considered to be entirely new.
+func GitChanges(rev string) (map[string][]LineRange, error) {
+	changes := map[string][]LineRange{}
+
+	// NOTE: `rev` is passed to `git diff` as-is, so we can't let it be parsed
+	// as an option (e.g., `--output=...`).
+	if strings.HasPrefix(rev, "-") {
+		return changes, fmt.Errorf("invalid revision '%s'", rev)
+	}
+
+	root, err := ExecuteWithInput("git", "", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return changes, fmt.Errorf("git rev-parse: %s", strings.TrimSpace(err.Error()))
+	}
+	root = strings.TrimSpace(root)
+
+	diff, err := ExecuteWithInput(
+		"git", "", "-C", root, "-c", "core.quotePath=false",
+		"diff", "--unified=0", "--no-color", "--no-ext-diff", "--diff-filter=AMR", rev, "--")
+	if err != nil {
+		return changes, fmt.Errorf("git diff: %s", strings.TrimSpace(err.Error()))
+	}
+
+	changes, err = parseDiff(diff, root)
+	if err != nil {
+		return changes, err
+	}
+
+	untracked, err := ExecuteWithInput(
+		"git", "", "-C", root, "-c", "core.quotePath=false",
+		"ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return changes, fmt.Errorf("git ls-files: %s", strings.TrimSpace(err.Error()))
+	}
+
+	for _, name := range strings.Split(untracked, "\n") {
+		if name = strings.TrimSpace(name); name != "" {
+			file := filepath.Join(root, filepath.FromSlash(name))
+			changes[file] = []LineRange{{1, math.MaxInt}}
+		}
+	}
+
+	return changes, nil
+}
+
+// parseDiff returns the lines added or modified by `diff`, the output of
+// `git diff --unified=0` run in `root`, keyed by absolute file path.
+func parseDiff(diff, root string) (map[string][]LineRange, error) {
+	changes := map[string][]LineRange{}
+
+	var file string
+
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "+++ ") {
+			file = ""
+			if name := strings.TrimPrefix(line, "+++ "); name != "/dev/null" {
+				file = filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(name, "b/")))
+			}
+		} else if m := hunkHeaderRE.FindStringSubmatch(line); m != nil && file != "" {
+			start, _ := strconv.Atoi(m[1])
+
+			count := 1
+			if m[2] != "" {
+				count, _ = strconv.Atoi(m[2])
+			}
+
+			if count > 0 {
+				// A count of 0 means that lines were only removed.
+				changes[file] = append(changes[file], LineRange{start, start + count - 1})
+			}
+		}
+	}
+
+	return changes, scanner.Err()
+}
@@ -0,0 +1,59 @@
+package system
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseDiff(t *testing.T) {
+	diff := `diff --git a/docs/a.md b/docs/a.md
+index 1111111..2222222 100644
+--- a/docs/a.md
++++ b/docs/a.md
+@@ -3 +3 @@ Title
+-Old line.
++New line.
+@@ -10,0 +11,2 @@ Section
++Added one.
++Added two.
+@@ -20,2 +21,0 @@
+-Removed one.
+-Removed two.
+diff --git a/new.md b/new.md
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/new.md
+@@ -0,0 +1,3 @@
++One.
++Two.
++Three.
+diff --git a/only-removed.md b/only-removed.md
+--- a/only-removed.md
++++ b/only-removed.md
+@@ -5,2 +4,0 @@
+-Gone.
+-Gone.
+`
+
+	root := filepath.Join("repo", "root")
+	changes, err := parseDiff(diff, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][]LineRange{
+		filepath.Join(root, "docs", "a.md"): {{3, 3}, {11, 12}},
+		filepath.Join(root, "new.md"):       {{1, 3}},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %v, got %v", expected, changes)
+	}
+}
+
+func TestGitChangesOption(t *testing.T) {
+	if _, err := GitChanges("--output=" + filepath.Join(t.TempDir(), "x")); err == nil {
+		t.Error("expected an error for a revision that looks like an option")
+	}
+}
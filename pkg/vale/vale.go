@@ -0,0 +1,312 @@
+// Package vale provides a public API for embedding Vale's linter in other Go
+// programs.
+//
+// Unlike the `vale` command, this package doesn't search for a `.vale.ini`
+// file: the configuration is built programmatically using a `Config`.
+//
+//	linter, err := vale.New(vale.Config{
+//		StylesPath: "/path/to/styles",
+//		BaseStyles: []string{"Vale", "MyStyle"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//
+//	alerts, err := linter.Lint(ctx, "This is is a test.", "test.md")
+package vale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/errata-ai/vale/v3/internal/core"
+	"github.com/errata-ai/vale/v3/internal/lint"
+	"github.com/errata-ai/vale/v3/internal/system"
+)
+
+// Config is the programmatic equivalent of a `.vale.ini` file.
+type Config struct {
+	// StylesPath is the directory containing styles, vocabularies, etc.
+	// Relative paths are resolved against the current working directory.
+	StylesPath string
+
+	// MinAlertLevel is the lowest severity to report ("suggestion",
+	// "warning", or "error"). It defaults to "suggestion".
+	MinAlertLevel string
+
+	// Vocab lists the vocabularies (from `<StylesPath>/config/vocabularies`)
+	// to load.
+	Vocab []string
+
+	// Accept and Reject are additional vocabulary terms, as they'd appear in
+	// an `accept.txt` or `reject.txt` file.
+	Accept []string
+	Reject []string
+
+	// BaseStyles and Checks apply to all files, like a `[*]` section.
+	//
+	// Checks maps a rule name (e.g., "Vale.Repetition") to "YES", "NO", or a
+	// severity level.
+	BaseStyles []string
+	Checks     map[string]string
+
+	// Sections are applied, in order, to files that match their globs.
+	Sections []Section
+
+	// Formats associates an unknown extension with a known one (e.g., "mdx"
+	// to "md"), like the `[formats]` section.
+	Formats map[string]string
+
+	IgnoredScopes []string
+	SkippedScopes []string
+}
+
+// Section is the equivalent of a syntax-specific (`[*.md]`) section of a
+// `.vale.ini` file.
+type Section struct {
+	Glob string
+
+	BaseStyles []string
+	Checks     map[string]string
+
+	BlockIgnores []string
+	TokenIgnores []string
+
+	// Lang is the language of the matched files (e.g., "en_US").
+	Lang string
+}
+
+// Action is a possible solution to an Alert.
+type Action struct {
+	Name   string   // the name of the action -- e.g, 'replace'
+	Params []string // a slice of parameters for the given action
+}
+
+// Alert represents a potential error in prose.
+type Alert struct {
+	Action      Action // a possible solution
+	Span        [2]int // the [begin, end] location within a line (1-based)
+	Check       string // the name of the check
+	Description string // why `Message` is meaningful
+	Link        string // reference material
+	Message     string // the output message
+	Severity    string // 'suggestion', 'warning', or 'error'
+	Match       string // the actual matched text
+	Line        int    // the source line (1-based)
+}
+
+// Linter lints text according to a Config.
+//
+// A Linter is safe for concurrent use, but calls are processed one at a time.
+type Linter struct {
+	mu     sync.Mutex
+	linter *lint.Linter
+}
+
+// New creates a Linter from the given configuration.
+func New(cfg Config) (*Linter, error) {
+	src, err := cfg.ini()
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := core.NewConfig(&core.CLIFlags{IgnoreGlobal: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = core.FromString(src, config, false); err != nil {
+		return nil, err
+	}
+
+	// Ignore patterns are regular expressions, which may contain commas, so
+	// we set them directly rather than through the INI source.
+	for _, sec := range cfg.Sections {
+		if len(sec.BlockIgnores) > 0 {
+			config.BlockIgnores[sec.Glob] = append(config.BlockIgnores[sec.Glob], sec.BlockIgnores...)
+		}
+		if len(sec.TokenIgnores) > 0 {
+			config.TokenIgnores[sec.Glob] = append(config.TokenIgnores[sec.Glob], sec.TokenIgnores...)
+		}
+	}
+
+	config.AcceptedTokens = append(config.AcceptedTokens, cfg.Accept...)
+	config.RejectedTokens = append(config.RejectedTokens, cfg.Reject...)
+
+	linter, err := lint.NewLinter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Linter{linter: linter}, nil
+}
+
+// Lint lints `text` as if it were the content of the file located at `path`.
+//
+// The path determines the text's format and which sections of the
+// configuration apply to it, but it doesn't need to exist.
+//
+// If `ctx` is canceled before linting finishes, Lint returns the context's
+// error and the in-progress work stops at the next block of text.
+func (l *Linter) Lint(ctx context.Context, text, path string) ([]Alert, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		alerts []Alert
+		err    error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if ctx.Err() != nil {
+			// We were canceled while waiting for another call to finish.
+			done <- result{err: ctx.Err()}
+			return
+		}
+
+		files, err := l.linter.LintStringContext(ctx, text, path)
+		done <- result{alerts: toAlerts(files), err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		return r.alerts, r.err
+	}
+}
+
+// LintReader lints the content of `r` as if it were the file located at
+// `path`.
+func (l *Linter) LintReader(ctx context.Context, r io.Reader, path string) ([]Alert, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return l.Lint(ctx, string(b), path)
+}
+
+func toAlerts(files []*core.File) []Alert {
+	alerts := []Alert{}
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		// NOTE: Notebook alerts are located relative to their cell, which
+		// `Alert` can't represent.
+		for _, a := range lint.LocateCells(f.Content, f.SortedAlerts()) {
+			alert := Alert{
+				Action: Action{
+					Name:   a.Action.Name,
+					Params: a.Action.Params,
+				},
+				Check:       a.Check,
+				Description: a.Description,
+				Link:        a.Link,
+				Message:     a.Message,
+				Severity:    a.Severity,
+				Match:       a.Match,
+				Line:        a.Line,
+			}
+			if len(a.Span) == 2 {
+				alert.Span = [2]int{a.Span[0], a.Span[1]}
+			}
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+// ini converts the configuration into its `.vale.ini` equivalent.
+func (c Config) ini() (string, error) {
+	var sb strings.Builder
+
+	writeKey := func(key string, values ...string) error {
+		for _, v := range values {
+			if strings.ContainsAny(v, "\r\n") {
+				return core.NewE100("vale.New", fmt.Errorf(
+					"the value of '%s' can't contain a newline", key))
+			}
+		}
+		if len(values) > 0 {
+			fmt.Fprintf(&sb, "%s = %s\n", key, strings.Join(values, ", "))
+		}
+		return nil
+	}
+
+	if c.StylesPath == "" {
+		return "", core.NewE100("vale.New", errors.New("a StylesPath is required"))
+	}
+
+	level := c.MinAlertLevel
+	if level == "" {
+		level = "suggestion"
+	}
+
+	for _, kv := range [][]string{
+		append([]string{"StylesPath"}, system.AbsPath(c.StylesPath)),
+		{"MinAlertLevel", level},
+		append([]string{"Vocab"}, c.Vocab...),
+		append([]string{"IgnoredScopes"}, c.IgnoredScopes...),
+		append([]string{"SkippedScopes"}, c.SkippedScopes...),
+	} {
+		if err := writeKey(kv[0], kv[1:]...); err != nil {
+			return "", err
+		}
+	}
+
+	if len(c.Formats) > 0 {
+		sb.WriteString("\n[formats]\n")
+		for _, k := range sortedKeys(c.Formats) {
+			if err := writeKey(k, c.Formats[k]); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	sections := []Section{{Glob: "*", BaseStyles: c.BaseStyles, Checks: c.Checks}}
+	for _, sec := range append(sections, c.Sections...) {
+		if sec.Glob == "" || strings.ContainsAny(sec.Glob, "]\r\n") {
+			return "", core.NewE100("vale.New", fmt.Errorf(
+				"invalid section glob '%s'", sec.Glob))
+		}
+
+		fmt.Fprintf(&sb, "\n[%s]\n", sec.Glob)
+		if err := writeKey("BasedOnStyles", sec.BaseStyles...); err != nil {
+			return "", err
+		}
+
+		if sec.Lang != "" {
+			if err := writeKey("Lang", sec.Lang); err != nil {
+				return "", err
+			}
+		}
+
+		for _, k := range sortedKeys(sec.Checks) {
+			if err := writeKey(k, sec.Checks[k]); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,125 @@
+package vale
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testRule = `extends: substitution
+message: "Use '%s' instead of '%s'."
+level: warning
+swap:
+  utilize: use
+`
+
+func newTestLinter(t *testing.T, cfg Config) *Linter {
+	t.Helper()
+
+	styles := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(styles, "Test"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	rule := filepath.Join(styles, "Test", "Sub.yml")
+	if err := os.WriteFile(rule, []byte(testRule), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.StylesPath = styles
+	linter, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return linter
+}
+
+func TestLint(t *testing.T) {
+	linter := newTestLinter(t, Config{
+		BaseStyles: []string{"Test"},
+		Checks:     map[string]string{"Vale.Repetition": "error"},
+		Sections: []Section{
+			{Glob: "*.txt", Checks: map[string]string{"Test.Sub": "NO"}},
+		},
+	})
+
+	alerts, err := linter.Lint(context.Background(), "We utilize this is is text.\n", "doc.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []string{}
+	for _, a := range alerts {
+		checks = append(checks, a.Check)
+	}
+
+	if strings.Join(checks, ",") != "Test.Sub,Vale.Repetition" {
+		t.Fatalf("unexpected alerts: %v", alerts)
+	}
+
+	if alerts[0].Line != 1 || alerts[0].Span != [2]int{4, 10} || alerts[0].Severity != "warning" {
+		t.Errorf("unexpected alert: %+v", alerts[0])
+	}
+
+	alerts, err = linter.LintReader(context.Background(), strings.NewReader("We utilize this.\n"), "doc.txt")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(alerts) != 0 {
+		t.Errorf("expected the section to disable 'Test.Sub', got %v", alerts)
+	}
+}
+
+func TestLintNotebook(t *testing.T) {
+	linter := newTestLinter(t, Config{BaseStyles: []string{"Test"}})
+
+	notebook := `{
+  "cells": [
+    {"cell_type": "markdown", "metadata": {}, "source": ["# Intro\n"]},
+    {
+      "cell_type": "markdown",
+      "metadata": {},
+      "source": ["Some text.\n", "We utilize this.\n"]
+    }
+  ]
+}
+`
+
+	alerts, err := linter.Lint(context.Background(), notebook, "doc.ipynb")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(alerts) != 1 {
+		t.Fatalf("expected one alert, got %v", alerts)
+	}
+
+	// The alert is located in the notebook's JSON, not in its cell.
+	if alerts[0].Line != 7 || alerts[0].Span != [2]int{38, 44} {
+		t.Errorf("unexpected location: %+v", alerts[0])
+	}
+}
+
+func TestLintCanceled(t *testing.T) {
+	linter := newTestLinter(t, Config{BaseStyles: []string{"Test"}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := linter.Lint(ctx, "We utilize this.", "doc.md")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New(Config{}); err == nil {
+		t.Error("expected an error for a missing StylesPath")
+	}
+
+	_, err := New(Config{StylesPath: t.TempDir(), Sections: []Section{{Glob: "*.md]"}}})
+	if err == nil {
+		t.Error("expected an error for an invalid section glob")
+	}
+}
@@ -0,0 +1,8 @@
+package main
+
+// NOTE: one
+// TODO: two
+/* FIXME
+ * XXX
+ */
+func main() {}
@@ -0,0 +1,7 @@
+<?php
+# NOTE: one
+# TODO: two
+/* FIXME
+   XXX
+ */
+echo "hi";
@@ -0,0 +1,11 @@
+local x = 1 -- NOTE: a trailing comment
+
+function f()
+    -- XXX: an indented comment
+    -- that keeps going.
+    return x
+end
+
+--[[ TODO: a block comment
+that spans
+three lines ]]
@@ -0,0 +1,8 @@
+<?php
+    echo 'test'; // XXX: a line comment.
+    /**
+     * NOTE: a doc comment
+     * with two lines.
+     */
+    echo 'test'; # TODO: a Perl-style comment.
+?>
@@ -15,6 +15,43 @@ Feature: CLI
             """
         And the exit status should be 0
 
+    Scenario: Lint with GitHub Actions output
+        When I test template "github"
+        Then the output should contain exactly:
+            """
+            ::notice file=test.md,line=3,col=1,endColumn=4,title=vale.Annotations::'NOTE' left in text
+            ::notice file=test.md,line=32,col=1,endColumn=3,title=vale.Annotations::'XXX' left in text
+            ::notice file=test.md,line=34,col=29,endColumn=32,title=vale.Annotations::'TODO' left in text
+            ::notice file=test.md,line=36,col=3,endColumn=6,title=vale.Annotations::'TODO' left in text
+            ::notice file=test.md,line=36,col=10,endColumn=12,title=vale.Annotations::'XXX' left in text
+            ::notice file=test.md,line=36,col=16,endColumn=20,title=vale.Annotations::'FIXME' left in text
+            ::notice file=test.md,line=40,col=21,endColumn=25,title=vale.Annotations::'FIXME' left in text
+            ::notice file=test.md,line=44,col=5,endColumn=8,title=vale.Annotations::'TODO' left in text
+            ::notice file=test.md,line=46,col=3,endColumn=6,title=vale.Annotations::'TODO' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Lint with Checkstyle output
+        When I test template "checkstyle"
+        Then the output should contain exactly:
+            """
+            <?xml version="1.0" encoding="UTF-8"?>
+            <checkstyle version="4.3">
+              <file name="test.md">
+                <error line="3" column="1" severity="info" message="&#39;NOTE&#39; left in text" source="vale.Annotations"></error>
+                <error line="32" column="1" severity="info" message="&#39;XXX&#39; left in text" source="vale.Annotations"></error>
+                <error line="34" column="29" severity="info" message="&#39;TODO&#39; left in text" source="vale.Annotations"></error>
+                <error line="36" column="3" severity="info" message="&#39;TODO&#39; left in text" source="vale.Annotations"></error>
+                <error line="36" column="10" severity="info" message="&#39;XXX&#39; left in text" source="vale.Annotations"></error>
+                <error line="36" column="16" severity="info" message="&#39;FIXME&#39; left in text" source="vale.Annotations"></error>
+                <error line="40" column="21" severity="info" message="&#39;FIXME&#39; left in text" source="vale.Annotations"></error>
+                <error line="44" column="5" severity="info" message="&#39;TODO&#39; left in text" source="vale.Annotations"></error>
+                <error line="46" column="3" severity="info" message="&#39;TODO&#39; left in text" source="vale.Annotations"></error>
+              </file>
+            </checkstyle>
+            """
+        And the exit status should be 0
+
     Scenario: Lint a file and a directory
         When I lint "test.json subdir1"
         Then the output should contain exactly:
@@ -195,3 +232,30 @@ Feature: CLI
             }
             """
         And the exit status should be 0
+
+    Scenario: Run a style's tests
+        When I invoke "test Demo" in "styletest"
+        Then the output should contain exactly:
+            """
+            PASS Demo.Annotations (bad.md)
+            PASS Demo.Annotations (valid.md)
+
+            2 passed, 0 failed, 0 rules without fixtures.
+            """
+        And the exit status should be 0
+
+    Scenario: Lint a test directory
+        When I invoke "test" in "commands"
+        Then the output should contain exactly:
+            """
+            test/test.md:3:1:vale.Annotations:'TODO' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Run a command that shares its name with a file
+        When I invoke "ls-metrics test/test.md" in "commands"
+        Then the output should contain:
+            """
+            "heading_h1": 1,
+            """
+        And the exit status should be 0
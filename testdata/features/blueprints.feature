@@ -13,7 +13,7 @@ Feature: Blueprints
             Petstore.yaml:407:8:Vale.Spelling:Did you really mean 'nonintegers'?
             Rule.yml:3:39:Vale.Repetition:'can' is repeated!
             github-workflow.json:14:24:Vale.Spelling:Did you really mean 'pull_request'?
-            github-workflow.json:213:222:Vale.Spelling:Did you really mean 'env'?
+            github-workflow.json:213:250:Vale.Spelling:Did you really mean 'env'?
             github-workflow.json:335:24:Vale.Spelling:Did you really mean 'pull_request'?
             github-workflow.json:494:264:Vale.Spelling:Did you really mean 'prereleased'?
             github-workflow.json:568:83:Vale.Spelling:Did you really mean 'job_id'?
@@ -23,3 +23,22 @@ Feature: Blueprints
             test.py:13:16:vale.Annotations:'XXX' left in text
             test.py:14:14:vale.Annotations:'NOTE' left in text
             """
+
+    Scenario: Front matter
+        When I test "frontmatter"
+        Then the output should contain exactly:
+            """
+            broken.md:1:1:Vale.FrontMatter:Unable to parse front matter: yaml: line 1: did not find expected ',' or ']'
+            broken.md:5:6:Meta.Typo:'typpo' is a typo.
+            test.adoc:2:30:Meta.Typo:'typpo' is a typo.
+            test.adoc:3:8:Meta.Title:Avoid 'simply' in titles.
+            test.adoc:7:14:Meta.Typo:'typpo' is a typo.
+            test.md:2:8:Meta.Title:Avoid 'Simply' in titles.
+            test.md:2:17:Meta.Typo:'typpo' is a typo.
+            test.md:5:3:Meta.Typo:'typpo' is a typo.
+            test.md:8:16:Meta.Typo:'typpo' is a typo.
+            test.md:11:14:Meta.Typo:'typpo' is a typo.
+            test.rst:2:10:Meta.Title:Avoid 'Simply' in titles.
+            test.rst:2:28:Meta.Typo:'typpo' is a typo.
+            test.rst:6:10:Meta.Typo:'typpo' is a typo.
+            """
@@ -8,6 +8,14 @@ Feature: Checks
             test.md:39:1:Checks.ScriptRE:Consider inserting a new section heading at this point.
             """
 
+    Scenario: Script context
+        When I test "checks/ScriptContext"
+        Then the output should contain exactly:
+            """
+            test.md:3:11:Scripts.VeryAdjective:Consider 'good' instead of 'very good' (.md).
+            test.md:5:1:Scripts.LongSentence:Sentences should have fewer than 8 words.
+            """
+
     Scenario: Metric
         When I test "checks/Metric"
         Then the output should contain exactly:
@@ -22,6 +30,27 @@ Feature: Checks
             test.md:9:5:Checks.MultiCapture:'NFL' has no definition
             """
 
+    Scenario: Cross-file consistency and conditional checks
+        When I test "checks/CrossFile"
+        Then the output should contain exactly:
+            """
+            a.md:5:22:Checks.CrossDefinition:'NFL' has no definition.
+            b.md:3:32:Checks.CrossConsistency:Inconsistent spelling of 'login'.
+            """
+
+    Scenario: Headings
+        When I test "checks/Headings"
+        Then the output should contain exactly:
+            """
+            test.adoc:5:6:Checks.Outline:'Deep' skips from h2 to h4 and is deeper than h3.
+            test.adoc:7:7:Checks.Outline:'Deeper' is deeper than h3.
+            test.md:9:6:Checks.Outline:'Requirements:' skips from h2 to h4, is deeper than h3, and ends with ':'.
+            test.md:11:4:Checks.Outline:'Install' duplicates a sibling heading.
+            test.md:13:3:Checks.Outline:'Another title' is an additional h1.
+            test.rst:11:1:Checks.Outline:'Usage' duplicates a sibling heading.
+            test.rst:14:1:Checks.Outline:'Notes.' ends with '.'.
+            """
+
     Scenario: Occurrence
         When I test "checks/Occurrence"
         Then the output should contain exactly:
@@ -51,10 +80,10 @@ Feature: Checks
             test.md:21:27:Vale.Repetition:'a' is repeated!
             test.md:23:56:Vale.Repetition:'completes' is repeated!
             test.md:25:130:Vale.Repetition:'registry' is repeated!
-            test.md:27:1:Vale.Repetition:'Mermaid' is repeated!
+            test.md:27:9:Vale.Repetition:'Mermaid' is repeated!
             test.md:29:17:Vale.Repetition:'one' is repeated!
             test.md:31:6:Vale.Repetition:'to' is repeated!
-            test.md:35:1:Vale.Repetition:'use' is repeated!
+            test.md:35:12:Vale.Repetition:'use' is repeated!
             text.rst:6:17:Vale.Repetition:'as' is repeated!
             text.rst:8:33:Vale.Repetition:'the' is repeated!
             text.rst:15:7:Vale.Repetition:'and' is repeated!
@@ -176,6 +176,29 @@ Feature: Lint
             test.py:35:8:vale.Annotations:'NOTE' left in text
             test.py:37:5:vale.Annotations:'TODO' left in text
             """
+
+    Scenario: Lint a Jupyter notebook
+        When I lint "test.ipynb"
+        Then the output should contain exactly:
+            """
+            test.ipynb:9:6:vale.Annotations:'NOTE' left in text
+            test.ipynb:10:19:vale.Annotations:'TODO' left in text
+            test.ipynb:29:8:vale.Annotations:'TODO' left in text
+            test.ipynb:30:29:vale.Annotations:'XXX' left in text
+            test.ipynb:42:72:vale.Annotations:'FIXME' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Lint a Jupyter notebook with GitHub Actions output
+        When I run command "--output=github test.ipynb"
+        Then the output should contain exactly:
+            """
+            ::notice file=test.ipynb,line=9,col=6,endColumn=9,title=vale.Annotations::'NOTE' left in text
+            ::notice file=test.ipynb,line=10,col=19,endColumn=22,title=vale.Annotations::'TODO' left in text
+            ::notice file=test.ipynb,line=29,col=8,endColumn=11,title=vale.Annotations::'TODO' left in text
+            ::notice file=test.ipynb,line=30,col=29,endColumn=31,title=vale.Annotations::'XXX' left in text
+            ::notice file=test.ipynb,line=42,col=72,endColumn=76,title=vale.Annotations::'FIXME' left in text
+            """
         And the exit status should be 0
 
     Scenario: Lint a C++ file
@@ -301,6 +324,7 @@ Feature: Lint
             test.php:3:8:vale.Annotations:'NOTE' left in text
             test.php:4:8:vale.Annotations:'FIXME' left in text
             test.php:6:33:vale.Annotations:'TODO' left in text
+            test.php:8:8:vale.Annotations:'XXX' left in text
             """
         And the exit status should be 0
 
@@ -324,6 +348,7 @@ Feature: Lint
             test.lua:5:7:vale.Annotations:'NOTE' left in text
             test.lua:9:6:vale.Annotations:'XXX' left in text
             test.lua:15:4:vale.Annotations:'TODO' left in text
+            test.lua:18:8:vale.Annotations:'FIXME' left in text
             """
         And the exit status should be 0
 
@@ -358,6 +383,82 @@ Feature: Lint
             """
         And the exit status should be 0
 
+    Scenario: Lint a Java file
+        When I lint "test.java"
+        Then the output should contain exactly:
+            """
+            test.java:2:4:vale.Annotations:'NOTE' left in text
+            test.java:5:8:vale.Annotations:'TODO' left in text
+            test.java:7:54:vale.Annotations:'FIXME' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Lint a C# file
+        When I lint "test.cs"
+        Then the output should contain exactly:
+            """
+            test.cs:2:5:vale.Annotations:'NOTE' left in text
+            test.cs:6:8:vale.Annotations:'TODO' left in text
+            test.cs:7:78:vale.Annotations:'FIXME' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Lint a shell file
+        When I lint "test.sh"
+        Then the output should contain exactly:
+            """
+            test.sh:2:3:vale.Annotations:'NOTE' left in text
+            test.sh:3:29:vale.Annotations:'TODO' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Lint a Swift file
+        When I lint "test.swift"
+        Then the output should contain exactly:
+            """
+            test.swift:1:5:vale.Annotations:'NOTE' left in text
+            test.swift:3:36:vale.Annotations:'TODO' left in text
+            test.swift:4:8:vale.Annotations:'FIXME' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Lint a Kotlin file
+        When I lint "test.kt"
+        Then the output should contain exactly:
+            """
+            test.kt:2:4:vale.Annotations:'NOTE' left in text
+            test.kt:5:38:vale.Annotations:'TODO' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Lint a Scala file
+        When I lint "test.scala"
+        Then the output should contain exactly:
+            """
+            test.scala:1:4:vale.Annotations:'NOTE' left in text
+            test.scala:3:6:vale.Annotations:'TODO' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Lint a SQL file
+        When I lint "test.sql"
+        Then the output should contain exactly:
+            """
+            test.sql:1:4:vale.Annotations:'NOTE' left in text
+            test.sql:2:44:vale.Annotations:'TODO' left in text
+            """
+        And the exit status should be 0
+
+    Scenario: Lint a Perl file
+        When I lint "test.pl"
+        Then the output should contain exactly:
+            """
+            test.pl:2:3:vale.Annotations:'NOTE' left in text
+            test.pl:3:33:vale.Annotations:'TODO' left in text
+            test.pl:7:1:vale.Annotations:'FIXME' left in text
+            """
+        And the exit status should be 0
+
 
     Scenario: Lint an assigned format
         When I lint path "subdir3"
@@ -86,10 +86,23 @@ Feature: Misc
         When I test "misc/markup"
         Then the output should contain exactly:
             """
-            test.md:4:1:Markup.Repetition:"in" is repeated.
+            test.md:4:428:Markup.Repetition:"in" is repeated.
             test.md:50:11:Markup.SentSpacing:"d.A" must contain one and only one space.
             """
 
+    Scenario: Markdown positions
+        When I test "misc/positions"
+        Then the output should contain exactly:
+            """
+            test.md:5:14:Vale.Spelling:Did you really mean 'typpo'?
+            test.md:7:11:Vale.Spelling:Did you really mean 'typpo'?
+            test.md:8:14:Vale.Spelling:Did you really mean 'typpo'?
+            test.md:10:23:Vale.Repetition:'the' is repeated!
+            test.md:18:17:Vale.Spelling:Did you really mean 'typpo'?
+            test.md:20:18:Vale.Spelling:Did you really mean 'typpo'?
+            test.md:23:3:Vale.Spelling:Did you really mean 'typpo'?
+            """
+
     Scenario: Spelling
         When I test "spelling"
         Then the output should contain exactly:
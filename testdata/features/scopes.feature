@@ -130,3 +130,25 @@ Feature: Scopes
             test.rst:10:3:rules.List:'TODO' left in text
             test.rst:14:4:rules.List:'XXX' left in text
             """
+
+    Scenario: Admonition
+        When I test scope "admonition"
+        Then the output should contain exactly:
+            """
+            test.rst:6:21:rules.Admonition:'TODO' left in text
+            test.rst:7:33:rules.Admonition:'TODO' left in text
+            test.rst:11:25:rules.Admonition:'FIXME' left in text
+            test.rst:14:15:rules.Admonition:'XXX' left in text
+            """
+
+    Scenario: String
+        When I test scope "string"
+        Then the output should contain exactly:
+            """
+            i18n.go:7:11:rules.String:Don't use 'Please' in a user-facing string.
+            i18n.go:7:18:rules.I18n:Use 'sign in' instead of 'log in'.
+            test.go:8:22:rules.String:Don't use 'please' in a user-facing string.
+            test.go:10:10:rules.String:Don't use 'Please' in a user-facing string.
+            test.tsx:2:18:rules.String:Don't use 'Please' in a user-facing string.
+            test.tsx:3:33:rules.String:Don't use 'Please' in a user-facing string.
+            """
@@ -182,3 +182,8 @@ When(/^I lint string "(.*)"$/) do |string|
     step %(I run `#{cmd} '#{string}'`)
   end
 end
+
+When(/^I invoke "(.*)" in "(.*)"$/) do |args, dir|
+  step %(I cd to "../../fixtures/#{dir}")
+  step %(I run `#{cmd} #{args}`)
+end
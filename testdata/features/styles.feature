@@ -28,7 +28,7 @@ Feature: Styles
             test.html:32:17:demo.ScopedHeading:'this is a heading' should be in title case
             test.md:1:1:demo.Reading:Grade level (7.13) too high!
             test.md:1:3:demo.HeadingStartsWithCapital:'this is a heading' should be capitalized
-            test.md:7:1:demo.HeadingStartsWithCapital:'this is another heading!' should be capitalized
+            test.md:7:3:demo.HeadingStartsWithCapital:'this is another heading!' should be capitalized
             test.md:12:1:demo.SentenceLength:Sentences should be less than 25 words
             test.md:14:121:demo.Filters:Did you really mean 'DBA'?
             test.md:14:159:demo.SentenceLength:Sentences should be less than 25 words
@@ -49,7 +49,7 @@ Feature: Styles
             test.md:30:38:demo.Ending-Preposition:Don't end a sentence with 'of.'
             test.md:32:61:demo.Ending-Preposition:Don't end a sentence with 'by.'
             test.md:36:1:demo.SentenceLength:Sentences should be less than 25 words
-            test.md:36:101:demo.Smart:Inconsistent use of '"' ('smart' mixed with 'dumb')
+            test.md:36:178:demo.Smart:Inconsistent use of '"' ('smart' mixed with 'dumb')
             test.md:38:6:demo.Contractions:Use 'are not' instead of 'aren't'
             test.md:40:1:demo.LookAround:The alert box text for CAUTION: can only use 'Caution:', 'Warning:', or 'Important:'.
             test.md:44:11:demo.Terms:Use 'phone' or 'mobile phone' instead of 'cell phone'.
@@ -0,0 +1,8 @@
+/// <summary>
+/// NOTE: this is a documentation comment.
+/// </summary>
+class Test
+{
+    // TODO: this is a line comment.
+    static void Main() => System.Console.WriteLine("XXX: not a comment"); /* FIXME: block */
+}
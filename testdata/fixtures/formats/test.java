@@ -0,0 +1,9 @@
+/**
+ * NOTE: this is a Javadoc comment.
+ */
+public class Test {
+    // TODO: this is a line comment.
+    public static void main(String[] args) {
+        System.out.println("XXX: not a comment"); /* FIXME: inline block */
+    }
+}
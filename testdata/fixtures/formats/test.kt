@@ -0,0 +1,6 @@
+/**
+ * NOTE: this is a KDoc comment.
+ */
+fun main() {
+    println("XXX: not a comment") // TODO: trailing comment.
+}
@@ -13,3 +13,9 @@ and on ]] print ("17")
 print ("17")
 
 -- TODO: 'This is a comment'
+
+function f()
+    -- FIXME: an indented comment
+    --  that keeps going.
+    return 17
+end
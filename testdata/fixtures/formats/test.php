@@ -4,4 +4,8 @@
        FIXME: this is too! */
     echo 'TODO is yet another test';
     echo ' FIXME Final Test'; # TODO: Here's Perl-style comment.
+    /**
+     * XXX: a doc comment
+     * with two lines.
+     */
 ?>
@@ -0,0 +1,9 @@
+#!/usr/bin/perl
+# NOTE: this is a comment.
+print "XXX: not a comment\n"; # TODO: trailing comment.
+
+=pod
+
+FIXME: this is POD.
+
+=cut
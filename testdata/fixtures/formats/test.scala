@@ -0,0 +1,5 @@
+// NOTE: this is a line comment.
+object Test {
+  /* TODO: this is a block comment. */
+  def main(args: Array[String]): Unit = println("XXX: not a comment")
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env bash
+# NOTE: this is a comment.
+echo "XXX: not a comment" # TODO: trailing comment.
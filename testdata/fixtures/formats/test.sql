@@ -0,0 +1,2 @@
+-- NOTE: this is a line comment.
+SELECT 'XXX: not a comment' FROM users; /* TODO: block comment */
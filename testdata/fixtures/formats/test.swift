@@ -0,0 +1,6 @@
+/// NOTE: this is a documentation comment.
+func test() {
+    print("XXX: not a comment") // TODO: trailing comment.
+    /* FIXME: this is a
+       multiline comment */
+}
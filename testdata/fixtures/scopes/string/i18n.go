@@ -0,0 +1,10 @@
+package main
+
+import "example.com/i18n"
+
+func labels() []string {
+	return []string{
+		i18n.T("Please log in"),
+		debug("please ignore this"),
+	}
+}
@@ -0,0 +1,13 @@
+package main
+
+import "errors"
+
+// Please note: this comment isn't a string.
+func check(ok bool) error {
+	if !ok {
+		return errors.New("please try again")
+	}
+	msg := `Please
+log in first`
+	return errors.New(msg)
+}
@@ -0,0 +1,4 @@
+export function Login() {
+  const title = "Please wait";
+  return <button title="please">Please log in</button>;
+}